@@ -5,13 +5,15 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceDbaasBackups() *schema.Resource {
 	return &schema.Resource{
-		Description: "Fetches the list of dbaas backups in the project",
+		Description: "Fetches the list of dbaas backups in the project, optionally filtered by type, cluster and age",
 		ReadContext: dataSourceDbaasBackupsRead,
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -19,6 +21,29 @@ func dataSourceDbaasBackups() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"type": {
+				Description:  "Only return backups of this type (`FULL`, `PARTIAL` or `INCREMENTAL`)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{fullBackup, "PARTIAL", "INCREMENTAL"}, false),
+			},
+			"cluster_id": {
+				Description: "Only return backups of this cluster",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"older_than": {
+				Description:  "Only return backups created longer ago than this, e.g. `30d` or `12h`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateBackupAge,
+			},
+			"newer_than": {
+				Description:  "Only return backups created more recently than this, e.g. `7d`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateBackupAge,
+			},
 			"result": {
 				Description: "The object that holds the results",
 				Type:        schema.TypeList,
@@ -50,6 +75,18 @@ func dataSourceDbaasBackupsRead(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	filter := backupFilter{
+		Type:      d.Get("type").(string),
+		ClusterID: d.Get("cluster_id").(string),
+	}
+	if v := d.Get("older_than").(string); v != "" {
+		filter.OlderThan, _ = parseBackupAge(v)
+	}
+	if v := d.Get("newer_than").(string); v != "" {
+		filter.NewerThan, _ = parseBackupAge(v)
+	}
+	backups = filter.apply(backups, time.Now())
+
 	res := make([]interface{}, 0, len(backups))
 	for _, b := range backups {
 		res = append(res, map[string]interface{}{
@@ -73,3 +110,38 @@ func dataSourceDbaasBackupsRead(ctx context.Context, d *schema.ResourceData, m i
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 	return nil
 }
+
+// backupFilter narrows a backup listing. Zero fields match everything.
+type backupFilter struct {
+	Type      string
+	ClusterID string
+	OlderThan time.Duration
+	NewerThan time.Duration
+}
+
+func (f backupFilter) apply(backups []cloapi.Backup, now time.Time) []cloapi.Backup {
+	var out []cloapi.Backup
+	for _, b := range backups {
+		if f.Type != "" && b.Type != f.Type {
+			continue
+		}
+		if f.ClusterID != "" && b.ClusterID != f.ClusterID {
+			continue
+		}
+		if f.OlderThan > 0 || f.NewerThan > 0 {
+			created, err := time.Parse(time.RFC3339, b.CreatedIn)
+			if err != nil {
+				continue
+			}
+			age := now.Sub(created)
+			if f.OlderThan > 0 && age <= f.OlderThan {
+				continue
+			}
+			if f.NewerThan > 0 && age >= f.NewerThan {
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package clo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+)
+
+func TestBackupFilter(t *testing.T) {
+	now := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	backups := []cloapi.Backup{
+		{ID: "full-old", ClusterID: "cl-1", Type: fullBackup, CreatedIn: "2026-09-01T00:00:00Z"},
+		{ID: "full-new", ClusterID: "cl-1", Type: fullBackup, CreatedIn: "2026-10-09T00:00:00Z"},
+		{ID: "partial", ClusterID: "cl-1", Type: "PARTIAL", CreatedIn: "2026-10-05T00:00:00Z"},
+		{ID: "other", ClusterID: "cl-2", Type: fullBackup, CreatedIn: "2026-10-05T00:00:00Z"},
+	}
+	ids := func(bs []cloapi.Backup) []string {
+		var out []string
+		for _, b := range bs {
+			out = append(out, b.ID)
+		}
+		return out
+	}
+	cases := []struct {
+		name   string
+		filter backupFilter
+		want   []string
+	}{
+		{"none", backupFilter{}, []string{"full-old", "full-new", "partial", "other"}},
+		{"type", backupFilter{Type: "PARTIAL"}, []string{"partial"}},
+		{"cluster", backupFilter{ClusterID: "cl-2"}, []string{"other"}},
+		{"older_than", backupFilter{ClusterID: "cl-1", OlderThan: 30 * 24 * time.Hour}, []string{"full-old"}},
+		{"newer_than", backupFilter{Type: fullBackup, NewerThan: 7 * 24 * time.Hour}, []string{"full-new", "other"}},
+		{"window", backupFilter{OlderThan: 2 * 24 * time.Hour, NewerThan: 7 * 24 * time.Hour}, []string{"partial", "other"}},
+	}
+	for _, c := range cases {
+		got := ids(c.filter.apply(backups, now))
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+				break
+			}
+		}
+	}
+}
@@ -61,6 +61,26 @@ func dataSourceLoadBalancerRules() *schema.Resource {
 							Type:        schema.TypeInt,
 							Computed:    true,
 						},
+						"protocol": {
+							Description: "Listener protocol (`TCP`, `HTTP` or `HTTPS`)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"certificate_id": {
+							Description: "ID of the certificate an `HTTPS` rule presents",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"x_forwarded_for": {
+							Description: "Whether an `X-Forwarded-For` header is added",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"redirect_http_to_https": {
+							Description: "Whether requests are redirected to HTTPS",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -74,25 +94,33 @@ func dataSourceLoadBalancerRulesRead(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if e := d.Set("result", flattenLoadBalancerRulesResults(rules)); e != nil {
+	listeners := make([]cloapi.RuleListener, len(rules))
+	for i, r := range rules {
+		if listeners[i], err = getRuleListener(ctx, cli, r.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if e := d.Set("result", flattenLoadBalancerRulesResults(rules, listeners)); e != nil {
 		return diag.FromErr(e)
 	}
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 	return nil
 }
 
-func flattenLoadBalancerRulesResults(rules []cloapi.Rule) []interface{} {
+// flattenLoadBalancerRulesResults pairs each rule with its listener options,
+// listeners[i] belonging to rules[i].
+func flattenLoadBalancerRulesResults(rules []cloapi.Rule, listeners []cloapi.RuleListener) []interface{} {
 	res := make([]interface{}, 0, len(rules))
-	for _, r := range rules {
-		res = append(res, map[string]interface{}{
-			"id":                     r.ID,
-			"loadbalancer":           r.Loadbalancer,
-			"address":                r.Address,
-			"server":                 r.Server,
-			"status":                 r.Status,
-			"external_protocol_port": r.ExternalProtocolPort,
-			"internal_protocol_port": r.InternalProtocolPort,
-		})
+	for i, r := range rules {
+		entry := flattenRuleListener(listeners[i])
+		entry["id"] = r.ID
+		entry["loadbalancer"] = r.Loadbalancer
+		entry["address"] = r.Address
+		entry["server"] = r.Server
+		entry["status"] = r.Status
+		entry["external_protocol_port"] = r.ExternalProtocolPort
+		entry["internal_protocol_port"] = r.InternalProtocolPort
+		res = append(res, entry)
 	}
 	return res
 }
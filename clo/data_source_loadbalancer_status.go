@@ -0,0 +1,159 @@
+package clo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// onlineBackend is the operating status of a backend passing its healthmonitor.
+const onlineBackend = "ONLINE"
+
+func dataSourceLoadBalancerStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Fetches the health of a load balancer's backends: per listener rule, whether the backend " +
+			"passes the healthmonitor and the result of the last check. `healthy` is true only when every rule " +
+			"is `ONLINE`, which makes it a convenient gate in a postcondition after `terraform apply -refresh-only`.",
+		ReadContext: dataSourceLoadBalancerStatusRead,
+		Schema: map[string]*schema.Schema{
+			"loadbalancer_id": {
+				Description: "ID of the load balancer to inspect",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"provisioning_status": {
+				Description: "Lifecycle status of the load balancer",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"operating_status": {
+				Description: "Aggregated health of the load balancer (`ONLINE`, `DEGRADED`, `OFFLINE`, `ERROR` or `NO_MONITOR`)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"healthy": {
+				Description: "Whether every rule's backend is `ONLINE`. False when the load balancer has no rules.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"result": {
+				Description: "Health of each listener rule's backend",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"address": {
+							Description: "Address the rule listens on",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"server": {
+							Description: "ID of the backend server the rule targets",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"external_protocol_port": {
+							Description: "Port exposed on the load balancer's address",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"internal_protocol_port": {
+							Description: "Port the traffic is forwarded to on the backend",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Lifecycle status of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"operating_status": {
+							Description: "Health of the backend (`ONLINE`, `OFFLINE`, `DEGRADED`, `ERROR` or `NO_MONITOR`)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_check_result": {
+							Description: "Outcome of the most recent health check, empty until the first one runs",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_check_at": {
+							Description: "Timestamp of the most recent health check",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_check_detail": {
+							Description: "Details of the most recent health check, e.g. the error of a failed probe",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLoadBalancerStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	st, err := cli.GetLoadBalancerStatus(ctx, d.Get("loadbalancer_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"provisioning_status": st.ProvisioningStatus,
+		"operating_status":    st.OperatingStatus,
+		"healthy":             allBackendsOnline(st.Rules),
+		"result":              flattenLoadBalancerStatusResults(st.Rules),
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return nil
+}
+
+// allBackendsOnline reports whether there is at least one rule and every
+// rule's backend is ONLINE. NO_MONITOR does not count as healthy: nothing
+// vouches for the backend.
+func allBackendsOnline(rules []cloapi.RuleStatus) bool {
+	if len(rules) == 0 {
+		return false
+	}
+	for _, r := range rules {
+		if r.OperatingStatus != onlineBackend {
+			return false
+		}
+	}
+	return true
+}
+
+func flattenLoadBalancerStatusResults(rules []cloapi.RuleStatus) []interface{} {
+	res := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		res = append(res, map[string]interface{}{
+			"id":                     r.ID,
+			"address":                r.Address,
+			"server":                 r.Server,
+			"external_protocol_port": r.ExternalProtocolPort,
+			"internal_protocol_port": r.InternalProtocolPort,
+			"status":                 r.ProvisioningStatus,
+			"operating_status":       r.OperatingStatus,
+			"last_check_result":      r.LastCheckResult,
+			"last_check_at":          r.LastCheckAt,
+			"last_check_detail":      r.LastCheckDetail,
+		})
+	}
+	return res
+}
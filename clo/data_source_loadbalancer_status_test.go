@@ -0,0 +1,26 @@
+package clo
+
+import (
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+)
+
+func TestAllBackendsOnline(t *testing.T) {
+	online := cloapi.RuleStatus{OperatingStatus: "ONLINE"}
+	cases := []struct {
+		name  string
+		rules []cloapi.RuleStatus
+		want  bool
+	}{
+		{name: "no_rules", want: false},
+		{name: "all_online", rules: []cloapi.RuleStatus{online, online}, want: true},
+		{name: "one_offline", rules: []cloapi.RuleStatus{online, {OperatingStatus: "OFFLINE"}}, want: false},
+		{name: "no_monitor", rules: []cloapi.RuleStatus{{OperatingStatus: "NO_MONITOR"}}, want: false},
+	}
+	for _, tc := range cases {
+		if got := allBackendsOnline(tc.rules); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package clo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceS3Usage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reports the user's quotas, in total and per bucket, and with `count_objects` how much of them " +
+			"the user consumes. Sizes are in bytes. The storage has no usage endpoint, so counting lists every object in " +
+			"every bucket of the user and takes longer the more objects the user has. `access_key` must be one of the " +
+			"user's key pairs.",
+		ReadContext: dataSourceS3UsageRead,
+		Schema: withS3Connection(withS3UsageFields(map[string]*schema.Schema{
+			"user_id": {
+				Description: "ID of the user, whose keys are given in `access_key` and `secret_key`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"count_objects": {
+				Description: "List every object of the user to report `size`, `objects`, the headroom and the utilization " +
+					"against the quotas. Without it only the quotas are reported and the usage attributes are null. " +
+					"Defaults to false.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"include_versions": {
+				Description: "Count noncurrent object versions too, as they take up storage. This lists every version " +
+					"of every object, which takes longer than listing the current objects. Only used with " +
+					"`count_objects`. Defaults to false.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"utilization_threshold": {
+				Description:  "Utilization, between 0 and 1, at which `over_threshold` is set. Defaults to 0.8.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0.8,
+				ValidateFunc: validation.FloatBetween(0, 1),
+			},
+			"bucket_count": {
+				Description: "How many buckets the user has",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"buckets": {
+				Description: "Usage of each bucket, against the user's bucket quota",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{Schema: withS3UsageFields(map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the bucket",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				})},
+			},
+		})),
+	}
+}
+
+// withS3UsageFields adds the usage and headroom attributes shared by the user
+// and each of their buckets.
+func withS3UsageFields(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	computed := map[string]struct {
+		typ         schema.ValueType
+		description string
+	}{
+		"size":                {schema.TypeInt, "Total size of the stored objects"},
+		"objects":             {schema.TypeInt, "How many objects are stored"},
+		"max_size":            {schema.TypeInt, "Size quota, or 0 if there is none"},
+		"max_objects":         {schema.TypeInt, "Object quota, or 0 if there is none"},
+		"size_headroom":       {schema.TypeInt, "Size left before the quota is reached, or -1 if there is no quota"},
+		"objects_headroom":    {schema.TypeInt, "Objects left before the quota is reached, or -1 if there is no quota"},
+		"size_utilization":    {schema.TypeFloat, "Share of the size quota in use, e.g. 0.8 for 80%, or 0 if there is no quota"},
+		"objects_utilization": {schema.TypeFloat, "Share of the object quota in use, e.g. 0.8 for 80%, or 0 if there is no quota"},
+		"over_threshold":      {schema.TypeBool, "Whether either utilization reached `utilization_threshold`"},
+	}
+	for k, v := range computed {
+		fields[k] = &schema.Schema{Description: v.description, Type: v.typ, Computed: true}
+	}
+	return fields
+}
+
+func dataSourceS3UsageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	userID := d.Get("user_id").(string)
+	user, err := cli.GetS3User(ctx, userID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The buckets are listed with access_key, so they are another user's if the
+	// key is, and would be measured against the wrong quotas.
+	if err := checkS3KeyOwner(ctx, cli, userID, d.Get("access_key").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	s3cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	counted := d.Get("count_objects").(bool)
+	var buckets []s3Usage
+	if counted {
+		buckets, err = collectS3Usage(ctx, s3cli, d.Get("include_versions").(bool))
+	} else {
+		buckets, err = listS3Buckets(ctx, s3cli)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	threshold := d.Get("utilization_threshold").(float64)
+	userQuota, bucketQuota := findS3Quota(user.Quotas, "user"), findS3Quota(user.Quotas, "bucket")
+	var total s3Usage
+	bucketList := make([]interface{}, 0, len(buckets))
+	for _, b := range buckets {
+		total.size += b.size
+		total.objects += b.objects
+		entry := flattenS3QuotaLimits(bucketQuota)
+		if counted {
+			entry = flattenS3Usage(b, bucketQuota, threshold)
+		}
+		entry["name"] = b.name
+		bucketList = append(bucketList, entry)
+	}
+
+	fields := flattenS3QuotaLimits(userQuota)
+	if counted {
+		fields = flattenS3Usage(total, userQuota, threshold)
+	}
+	fields["bucket_count"] = len(buckets)
+	fields["buckets"] = bucketList
+	for k, v := range fields {
+		if e := d.Set(k, v); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	d.SetId(userID)
+	return nil
+}
+
+// s3Usage is the size in bytes and object count of a bucket, or of all of them.
+type s3Usage struct {
+	name    string
+	size    int64
+	objects int64
+}
+
+// checkS3KeyOwner fails unless accessKey is one of the user's key pairs or
+// their default pair.
+func checkS3KeyOwner(ctx context.Context, cli *cloapi.Client, userID, accessKey string) error {
+	keys, err := cli.ListS3UserKeys(ctx, userID)
+	if err != nil && !errors.Is(err, cloapi.ErrPreviewDisabled) {
+		return err
+	}
+	for _, k := range keys {
+		if k.AccessKey == accessKey {
+			return nil
+		}
+	}
+	def, err := cli.GetS3UserAccessKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if def != "" && def == accessKey {
+		return nil
+	}
+	return fmt.Errorf("access_key is not a key of s3 user %s", userID)
+}
+
+// listS3Buckets returns the buckets the client's user owns, with no usage
+// counted.
+func listS3Buckets(ctx context.Context, cli *s3api.Client) ([]s3Usage, error) {
+	buckets, err := cli.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]s3Usage, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, s3Usage{name: b.Name})
+	}
+	return out, nil
+}
+
+// collectS3Usage adds up the objects of every bucket the client's user owns.
+// With versions, noncurrent versions are counted as well; delete markers take
+// no space and are not.
+func collectS3Usage(ctx context.Context, cli *s3api.Client, versions bool) ([]s3Usage, error) {
+	buckets, err := cli.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]s3Usage, 0, len(buckets))
+	for _, b := range buckets {
+		u := s3Usage{name: b.Name}
+		if versions {
+			vs, err := cli.ListObjectVersions(ctx, b.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vs {
+				if v.DeleteMarker {
+					continue
+				}
+				u.size += v.Size
+				u.objects++
+			}
+		} else {
+			objs, err := cli.ListObjects(ctx, b.Name, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objs {
+				u.size += o.Size
+				u.objects++
+			}
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// findS3Quota returns the quota of the given type, or a zero quota, meaning no
+// limits, when the user has none.
+func findS3Quota(quotas []cloapi.S3Quota, typ string) cloapi.S3Quota {
+	for _, q := range quotas {
+		if strings.EqualFold(q.Type, typ) {
+			return q
+		}
+	}
+	return cloapi.S3Quota{}
+}
+
+// flattenS3QuotaLimits sets the quota attributes of withS3UsageFields for q,
+// whose size is in GiB as the API reports it.
+func flattenS3QuotaLimits(q cloapi.S3Quota) map[string]interface{} {
+	return map[string]interface{}{
+		"max_size":    q.MaxSize << 30,
+		"max_objects": q.MaxObjects,
+	}
+}
+
+// flattenS3Usage sets every withS3UsageFields attribute for usage against q.
+func flattenS3Usage(u s3Usage, q cloapi.S3Quota, threshold float64) map[string]interface{} {
+	maxSize := int64(q.MaxSize) << 30
+	maxObjects := int64(q.MaxObjects)
+	sizeHeadroom, sizeUtilization := s3Headroom(u.size, maxSize)
+	objectsHeadroom, objectsUtilization := s3Headroom(u.objects, maxObjects)
+	fields := flattenS3QuotaLimits(q)
+	fields["size"] = int(u.size)
+	fields["objects"] = int(u.objects)
+	fields["size_headroom"] = int(sizeHeadroom)
+	fields["objects_headroom"] = int(objectsHeadroom)
+	fields["size_utilization"] = sizeUtilization
+	fields["objects_utilization"] = objectsUtilization
+	fields["over_threshold"] = (maxSize > 0 && sizeUtilization >= threshold) ||
+		(maxObjects > 0 && objectsUtilization >= threshold)
+	return fields
+}
+
+// s3Headroom is what is left of limit and the share of it used. Without a limit
+// the headroom is -1 and the share 0. Usage over the limit gives no headroom.
+func s3Headroom(used, limit int64) (int64, float64) {
+	if limit <= 0 {
+		return -1, 0
+	}
+	headroom := limit - used
+	if headroom < 0 {
+		headroom = 0
+	}
+	return headroom, float64(used) / float64(limit)
+}
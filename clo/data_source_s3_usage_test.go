@@ -0,0 +1,87 @@
+package clo
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+)
+
+func TestCollectS3UsageAgainstStandIn(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	srv.CreateBucket("media")
+	srv.CreateBucket("empty")
+	for _, k := range []string{"a", "b", "c"} {
+		srv.PutObject("media", k, []byte(strings.Repeat("x", 10)))
+	}
+	cli, err := s3api.New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := listS3Buckets(context.Background(), cli)
+	if err != nil {
+		t.Fatalf("listS3Buckets: %v", err)
+	}
+	if want := []s3Usage{{name: "empty"}, {name: "media"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("buckets = %+v, want %+v", got, want)
+	}
+
+	// The stand-in keeps a single version per key, so both listings agree.
+	want := []s3Usage{{name: "empty"}, {name: "media", size: 30, objects: 3}}
+	for _, versions := range []bool{false, true} {
+		got, err := collectS3Usage(context.Background(), cli, versions)
+		if err != nil {
+			t.Fatalf("collectS3Usage(versions=%v): %v", versions, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("usage (versions=%v) = %+v, want %+v", versions, got, want)
+		}
+	}
+}
+
+func TestFlattenS3Usage(t *testing.T) {
+	u := s3Usage{size: 900 << 20, objects: 50}
+
+	got := flattenS3Usage(u, cloapi.S3Quota{Type: "user", MaxSize: 1, MaxObjects: 1000}, 0.8)
+	if got["max_size"] != 1<<30 || got["size_headroom"] != 124<<20 || got["objects_headroom"] != 950 {
+		t.Errorf("unexpected limits %v", got)
+	}
+	if got["objects_utilization"] != 0.05 || got["over_threshold"] != true {
+		t.Errorf("900MiB of 1GiB should be over an 80%% threshold, got %v", got)
+	}
+
+	got = flattenS3Usage(u, cloapi.S3Quota{}, 0.8)
+	if got["size_headroom"] != -1 || got["size_utilization"] != 0.0 || got["over_threshold"] != false {
+		t.Errorf("usage without quotas should report no headroom limit, got %v", got)
+	}
+
+	got = flattenS3Usage(s3Usage{size: 2 << 30}, cloapi.S3Quota{MaxSize: 1}, 0.8)
+	if got["size_headroom"] != 0 || got["size_utilization"] != 2.0 {
+		t.Errorf("usage over the quota should leave no headroom, got %v", got)
+	}
+}
+
+func TestFlattenS3QuotaLimits(t *testing.T) {
+	got := flattenS3QuotaLimits(cloapi.S3Quota{Type: "user", MaxSize: 2, MaxObjects: 1000})
+	want := map[string]interface{}{"max_size": 2 << 30, "max_objects": 1000}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenS3QuotaLimits = %v, want %v", got, want)
+	}
+}
+
+func TestFindS3Quota(t *testing.T) {
+	quotas := []cloapi.S3Quota{{Type: "USER", MaxSize: 10}, {Type: "bucket", MaxSize: 2}}
+	if q := findS3Quota(quotas, "user"); q.MaxSize != 10 {
+		t.Errorf("user quota = %+v", q)
+	}
+	if q := findS3Quota(quotas[:1], "bucket"); q != (cloapi.S3Quota{}) {
+		t.Errorf("missing quota should be empty, got %+v", q)
+	}
+}
@@ -2,16 +2,18 @@ package clo
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"time"
 
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceS3Keys() *schema.Resource {
 	return &schema.Resource{
-		Description: "Fetches the data of S3 user's keys",
+		Description: "Fetches the data of S3 user's keys: the default access key and every key pair the user holds",
 		ReadContext: dataSourceS3KeysRead,
 		Schema: map[string]*schema.Schema{
 			"user_id": {
@@ -27,6 +29,30 @@ func dataSourceS3Keys() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"keys": {
+				Description: "Key pairs of the user, without their secret keys. Empty unless the provider's `preview_endpoints` is enabled.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the key pair",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"access_key": {
+							Description: "Access key of the pair",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_in": {
+							Description: "Timestamp the pair was issued",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -35,13 +61,29 @@ func dataSourceS3KeysRead(ctx context.Context, d *schema.ResourceData, m interfa
 	cli := m.(*providerMeta).v3
 	// The v3 API returns only the access key on read; the secret key is available
 	// only at key generation, so secret_key stays empty here.
-	accessKey, err := cli.GetS3UserAccessKey(ctx, d.Get("user_id").(string))
+	uId := d.Get("user_id").(string)
+	accessKey, err := cli.GetS3UserAccessKey(ctx, uId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	if e := d.Set("access_key", accessKey); e != nil {
 		return diag.FromErr(e)
 	}
+	keys, err := cli.ListS3UserKeys(ctx, uId)
+	if err != nil && !errors.Is(err, cloapi.ErrPreviewDisabled) {
+		return diag.FromErr(err)
+	}
+	res := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, map[string]interface{}{
+			"id":         k.ID,
+			"access_key": k.AccessKey,
+			"created_in": k.CreatedIn,
+		})
+	}
+	if e := d.Set("keys", res); e != nil {
+		return diag.FromErr(e)
+	}
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 	return nil
 }
@@ -0,0 +1,87 @@
+package clo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVrouterNatRules() *schema.Resource {
+	return &schema.Resource{
+		Description: "Fetches the list of NAT (port-forwarding) rules on a virtual router",
+		ReadContext: dataSourceVrouterNatRulesRead,
+		Schema: map[string]*schema.Schema{
+			"vrouter_id": {
+				Description: "ID of the virtual router that owns the rules",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"result": {
+				Description: "The object that holds the results",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"protocol": {
+							Description: "Forwarded protocol",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"external_port": {
+							Description: "Port on the router's gateway address",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"internal_address": {
+							Description: "Private address the traffic is forwarded to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"internal_port": {
+							Description: "Port on `internal_address` the traffic is forwarded to",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Lifecycle status of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVrouterNatRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	rules, err := cli.ListVrouterNatRules(ctx, d.Get("vrouter_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		res = append(res, map[string]interface{}{
+			"id":               r.ID,
+			"protocol":         r.Protocol,
+			"external_port":    r.ExternalPort,
+			"internal_address": r.InternalAddress,
+			"internal_port":    r.InternalPort,
+			"status":           r.Status,
+		})
+	}
+	if e := d.Set("result", res); e != nil {
+		return diag.FromErr(e)
+	}
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return nil
+}
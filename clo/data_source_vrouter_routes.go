@@ -0,0 +1,75 @@
+package clo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVrouterRoutes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Fetches the list of static routes on a virtual router",
+		ReadContext: dataSourceVrouterRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"vrouter_id": {
+				Description: "ID of the virtual router that owns the routes",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"result": {
+				Description: "The object that holds the results",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the route",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"destination": {
+							Description: "Destination network in CIDR notation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"nexthop": {
+							Description: "Address the traffic for `destination` is forwarded to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Lifecycle status of the route",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVrouterRoutesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	routes, err := cli.ListVrouterRoutes(ctx, d.Get("vrouter_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res := make([]interface{}, 0, len(routes))
+	for _, r := range routes {
+		res = append(res, map[string]interface{}{
+			"id":          r.ID,
+			"destination": r.Destination,
+			"nexthop":     r.Nexthop,
+			"status":      r.Status,
+		})
+	}
+	if e := d.Set("result", res); e != nil {
+		return diag.FromErr(e)
+	}
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return nil
+}
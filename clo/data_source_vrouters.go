@@ -36,7 +36,7 @@ func dataSourceVrouters() *schema.Resource {
 							Computed:    true,
 						},
 						"status": {
-							Description: "Lifecycle status of the virtual router (CREATING/ACTIVE/STARTING/STOPPING/STOPPED/DELETING/DELETED/ERROR)",
+							Description: "Lifecycle status of the virtual router (CREATING/ACTIVE/STARTING/STOPPING/STOPPED/UPDATING/DELETING/DELETED/ERROR)",
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
@@ -20,6 +20,14 @@ var (
 
 func init() {
 	testAccProvider = Provider()
+	if testAccPreview() {
+		for name, r := range pendingResources() {
+			testAccProvider.ResourcesMap[name] = r
+		}
+		for name, r := range pendingDataSources() {
+			testAccProvider.DataSourcesMap[name] = r
+		}
+	}
 	testAccProviders = map[string]func() (*schema.Provider, error){
 		"clo": func() (*schema.Provider, error) {
 			return testAccProvider, nil
@@ -40,6 +48,14 @@ func skipIfNotAcc(t *testing.T) {
 	}
 }
 
+// skipIfNotPreview skips a test, or the rest of one, that needs the preview
+// endpoints unless CLO_PREVIEW_ENDPOINTS is set.
+func skipIfNotPreview(t *testing.T) {
+	if !testAccPreview() {
+		t.Skip("preview endpoints test skipped; set CLO_PREVIEW_ENDPOINTS=true to run")
+	}
+}
+
 func testAccCloPreCheck(t *testing.T) {
 	if _, b := os.LookupEnv("CLO_API_PROJECT_ID"); !b {
 		t.Fatal("CLO_API_PROJECT_ID env should be provided")
@@ -0,0 +1,213 @@
+package clo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description: "Upload a TLS certificate for `HTTPS` load balancer rules. The certificate chain and private key " +
+			"are checked locally at plan time: the key must match the leaf certificate, the chain must be in order " +
+			"(leaf first) and the leaf must be currently valid. The private key is stored in the Terraform state.",
+		ReadContext:   resourceCertificateRead,
+		CreateContext: resourceCertificateCreate,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
+		CustomizeDiff: validateCertificateDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project where the certificate should be uploaded",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the certificate",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"certificate": {
+				Description: "PEM-encoded certificate followed by its intermediates, leaf first",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"private_key": {
+				Description: "PEM-encoded private key of the leaf certificate",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"id": {
+				Description: "ID of the certificate",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"domains": {
+				Description: "Domain names the certificate is valid for",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fingerprint": {
+				Description: "SHA-256 fingerprint of the leaf certificate",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"expires_at": {
+				Description: "Timestamp the leaf certificate expires",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the certificate",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id, err := cli.CreateCertificate(ctx, d.Get("project_id").(string), cloapi.CertificateCreateParams{
+		Name:        d.Get("name").(string),
+		Certificate: d.Get("certificate").(string),
+		PrivateKey:  d.Get("private_key").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	return resourceCertificateRead(ctx, d, m)
+}
+
+func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	c, err := cli.GetCertificate(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// certificate and private_key are write-only and stay as configured.
+	fields := map[string]interface{}{
+		"id":          c.ID,
+		"project_id":  c.Project,
+		"name":        c.Name,
+		"domains":     c.Domains,
+		"fingerprint": c.Fingerprint,
+		"expires_at":  c.NotAfter,
+		"status":      c.Status,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChange("name") {
+		if err := cli.RenameCertificate(ctx, d.Id(), d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceCertificateRead(ctx, d, m)
+}
+
+func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeleteCertificate(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// validateCertificateDiff checks a new certificate/key pair before it is
+// uploaded. An already uploaded certificate is not re-checked, so its expiry
+// does not block unrelated plans.
+func validateCertificateDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("certificate") && !d.HasChange("private_key") {
+		return nil
+	}
+	if !d.NewValueKnown("certificate") || !d.NewValueKnown("private_key") {
+		return nil
+	}
+	_, err := validateCertificatePair(d.Get("certificate").(string), d.Get("private_key").(string), time.Now())
+	return err
+}
+
+// validateCertificatePair checks that keyPEM is the private key of the first
+// certificate in certPEM, that every following certificate signed the one
+// before it, and that the leaf is valid at now. It returns the parsed leaf.
+func validateCertificatePair(certPEM, keyPEM string, now time.Time) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("certificate and private_key do not form a valid pair: %w", err)
+	}
+
+	chain := make([]*x509.Certificate, 0, len(pair.Certificate))
+	for i, der := range pair.Certificate {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("certificate #%d in the chain: %w", i+1, err)
+		}
+		chain = append(chain, c)
+	}
+	for i := 0; i+1 < len(chain); i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate #%d (%s) is not signed by the next one in the chain (%s); list the leaf first, then each issuer: %w",
+				i+1, chain[i].Subject.CommonName, chain[i+1].Subject.CommonName, err)
+		}
+	}
+
+	leaf := chain[0]
+	if now.Before(leaf.NotBefore) {
+		return nil, fmt.Errorf("certificate is not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("certificate expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if t := nonCertificatePEM(certPEM); t != "" {
+		return nil, errors.New("certificate contains a non-certificate PEM block: " + t)
+	}
+	return leaf, nil
+}
+
+// nonCertificatePEM returns the type of the first PEM block in s that is not a
+// certificate (e.g. a private key pasted into the chain), or "".
+func nonCertificatePEM(s string) string {
+	rest := []byte(s)
+	for {
+		var b *pem.Block
+		b, rest = pem.Decode(rest)
+		if b == nil {
+			return ""
+		}
+		if b.Type != "CERTIFICATE" {
+			return b.Type
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package clo
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const certificateName = "cert_1"
+
+func TestAccCloCertificate_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	now := time.Now()
+	leafPEM, keyPEM, _ := testIssueCertificate(t, "example.com", now.Add(-time.Hour), now.Add(24*time.Hour), nil)
+
+	cert := new(cloapi.Certificate)
+	resName := fmt.Sprintf("clo_network_certificate.%s", certificateName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckCertificateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloCertificateBasic(leafPEM, keyPEM),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCertificateExists(resName, cert),
+					resource.TestCheckResourceAttr(resName, "name", certificateName),
+					resource.TestCheckResourceAttrSet(resName, "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateCertificatePair(t *testing.T) {
+	now := time.Now()
+	caPEM, caKeyPEM, ca := testIssueCertificate(t, "test-ca", now.Add(-time.Hour), now.Add(48*time.Hour), nil)
+	leafPEM, leafKeyPEM, _ := testIssueCertificate(t, "example.com", now.Add(-time.Hour), now.Add(24*time.Hour), ca)
+	_, otherKeyPEM, _ := testIssueCertificate(t, "other.com", now.Add(-time.Hour), now.Add(24*time.Hour), nil)
+
+	cases := []struct {
+		name    string
+		cert    string
+		key     string
+		at      time.Time
+		wantErr string
+	}{
+		{name: "leaf_only", cert: leafPEM, key: leafKeyPEM, at: now},
+		{name: "leaf_then_issuer", cert: leafPEM + caPEM, key: leafKeyPEM, at: now},
+		{name: "key_mismatch", cert: leafPEM, key: otherKeyPEM, at: now, wantErr: "valid pair"},
+		{name: "chain_reversed", cert: caPEM + leafPEM, key: caKeyPEM, at: now, wantErr: "not signed by the next one"},
+		{name: "expired", cert: leafPEM, key: leafKeyPEM, at: now.Add(25 * time.Hour), wantErr: "expired at"},
+		{name: "not_yet_valid", cert: leafPEM, key: leafKeyPEM, at: now.Add(-2 * time.Hour), wantErr: "not valid before"},
+		{name: "key_in_chain", cert: leafPEM + leafKeyPEM, key: leafKeyPEM, at: now, wantErr: "non-certificate PEM block"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validateCertificatePair(tc.cert, tc.key, tc.at)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+type testCA struct {
+	*x509.Certificate
+	key *ecdsa.PrivateKey
+}
+
+// testIssueCertificate returns a PEM certificate and key for cn, signed by
+// parent (self-signed when parent is nil), along with the parsed certificate.
+func testIssueCertificate(t *testing.T, cn string, notBefore, notAfter time.Time, parent *testCA) (string, string, *testCA) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              []string{cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	issuer, signer := tmpl, key
+	if parent != nil {
+		issuer, signer = parent.Certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, &testCA{Certificate: parsed, key: key}
+}
+
+func testAccCloCertificateBasic(certPEM, keyPEM string) string {
+	return fmt.Sprintf(`resource "clo_network_certificate" "%s" {
+	project_id  = "%s"
+	name        = "%s"
+	certificate = <<-EOT
+%sEOT
+	private_key = <<-EOT
+%sEOT
+}`, certificateName, projectID, certificateName, certPEM, keyPEM)
+}
+
+func testAccCheckCertificateExists(n string, item *cloapi.Certificate) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("certificate ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		c, e := cli.GetCertificate(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *c
+		return nil
+	}
+}
+
+func testAccCheckCertificateDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_network_certificate" {
+			continue
+		}
+		_, e := cli.GetCertificate(context.Background(), rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("certificate %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package clo
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
@@ -17,6 +20,8 @@ const (
 	availableBackup = "AVAILABLE"
 	deletingBackup  = "DELETING"
 	deletedBackup   = "DELETED"
+
+	fullBackup = "FULL"
 )
 
 func resourceDbaasBackup() *schema.Resource {
@@ -26,10 +31,11 @@ func resourceDbaasBackup() *schema.Resource {
 		CreateContext: resourceDbaasBackupCreate,
 		UpdateContext: resourceDbaasBackupUpdate,
 		DeleteContext: resourceDbaasBackupDelete,
+		CustomizeDiff: planDbaasBackupExpiry,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
@@ -60,6 +66,28 @@ func resourceDbaasBackup() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"expire_after": {
+				Description:  "How long after its creation the backup expires, e.g. `72h` or `30d`. Once expired, the next apply deletes the backup but keeps the resource, so it is not taken again.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateBackupAge,
+			},
+			"prevent_destroy_if_latest": {
+				Description: "Refuse to delete or expire the backup while it is the newest available backup of its type for the cluster.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"expires_at": {
+				Description: "Timestamp the backup expires at, when `expire_after` is set",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"expired": {
+				Description: "Whether the backup has expired and been deleted",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"id": {
 				Description: "ID of the backup",
 				Type:        schema.TypeString,
@@ -140,6 +168,11 @@ func resourceDbaasBackupCreate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceDbaasBackupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
+	// An expired backup has been deleted on purpose; keep the resource so it is
+	// not taken again.
+	if d.Get("expired").(bool) {
+		return nil
+	}
 	b, err := cli.GetBackup(ctx, d.Id())
 	if cloapi.IsNotFound(err) {
 		d.SetId("")
@@ -163,6 +196,12 @@ func resourceDbaasBackupRead(ctx context.Context, d *schema.ResourceData, m inte
 		"datastore_name":    b.DatastoreName,
 		"datastore_version": b.DatastoreVersion,
 		"created_in":        b.CreatedIn,
+		"expires_at":        "",
+	}
+	if v := d.Get("expire_after").(string); v != "" {
+		if at, ok := backupExpiresAt(b.CreatedIn, v); ok {
+			fields["expires_at"] = at.Format(time.RFC3339)
+		}
 	}
 	for k, val := range fields {
 		if e := d.Set(k, val); e != nil {
@@ -172,23 +211,166 @@ func resourceDbaasBackupRead(ctx context.Context, d *schema.ResourceData, m inte
 	return nil
 }
 
-// resourceDbaasBackupUpdate handles changes to force_delete only (every other
-// field is ForceNew); it takes no API call and just refreshes state.
+// resourceDbaasBackupUpdate deletes the backup once planDbaasBackupExpiry has
+// marked it expired. The other in-place fields (force_delete, expire_after,
+// prevent_destroy_if_latest) take no API call.
 func resourceDbaasBackupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("expired") && d.Get("expired").(bool) {
+		if err := deleteDbaasBackup(ctx, d, m.(*providerMeta).v3, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+		if e := d.Set("status", deletedBackup); e != nil {
+			return diag.FromErr(e)
+		}
+		return nil
+	}
 	return resourceDbaasBackupRead(ctx, d, m)
 }
 
 func resourceDbaasBackupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	cli := m.(*providerMeta).v3
-	if err := cli.DeleteBackup(ctx, d.Id(), d.Get("force_delete").(bool)); err != nil {
-		return diag.FromErr(err)
+	if d.Get("expired").(bool) {
+		return nil
 	}
-	if err := waitBackupDeleted(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+	if err := deleteDbaasBackup(ctx, d, m.(*providerMeta).v3, d.Timeout(schema.TimeoutDelete)); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
+func deleteDbaasBackup(ctx context.Context, d *schema.ResourceData, cli *cloapi.Client, timeout time.Duration) error {
+	if d.Get("prevent_destroy_if_latest").(bool) {
+		latest, err := isLatestBackup(ctx, cli, d.Id())
+		if err != nil {
+			return err
+		}
+		if latest {
+			return fmt.Errorf("backup %s is the newest of its type for its cluster and prevent_destroy_if_latest is set", d.Id())
+		}
+	}
+	if err := cli.DeleteBackup(ctx, d.Id(), d.Get("force_delete").(bool)); err != nil && !cloapi.IsNotFound(err) {
+		return err
+	}
+	return waitBackupDeleted(ctx, d.Id(), cli, timeout)
+}
+
+// planDbaasBackupExpiry plans the deletion of a backup whose expire_after has
+// passed, by moving expired to true. A backup guarded by
+// prevent_destroy_if_latest is kept while it is the newest of its kind.
+func planDbaasBackupExpiry(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange("expire_after") {
+		if err := d.SetNewComputed("expires_at"); err != nil {
+			return err
+		}
+	}
+	if d.Id() == "" || d.Get("expired").(bool) {
+		return nil
+	}
+	at, ok := backupExpiresAt(d.Get("created_in").(string), d.Get("expire_after").(string))
+	if !ok || time.Now().Before(at) {
+		return nil
+	}
+	if d.Get("prevent_destroy_if_latest").(bool) {
+		latest, err := isLatestBackup(ctx, m.(*providerMeta).v3, d.Id())
+		if err != nil {
+			return err
+		}
+		if latest {
+			return nil
+		}
+	}
+	if err := d.SetNewComputed("status"); err != nil {
+		return err
+	}
+	return d.SetNew("expired", true)
+}
+
+// isLatestBackup reports whether the backup is the newest available one of its
+// type for its cluster.
+func isLatestBackup(ctx context.Context, cli *cloapi.Client, id string) (bool, error) {
+	b, err := cli.GetBackup(ctx, id)
+	if cloapi.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	backups, err := cli.ListBackups(ctx, b.Project)
+	if err != nil {
+		return false, err
+	}
+	return latestBackupID(backups, b.ClusterID, b.Type) == id, nil
+}
+
+// latestBackupID returns the ID of the newest available backup of the given
+// type for the cluster, or "" when there is none.
+func latestBackupID(backups []cloapi.Backup, clusterID, typ string) string {
+	var latest string
+	var latestAt time.Time
+	for _, b := range backups {
+		if b.ClusterID != clusterID || b.Type != typ || b.Status != availableBackup {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, b.CreatedIn)
+		if err != nil {
+			continue
+		}
+		if latest == "" || created.After(latestAt) {
+			latest, latestAt = b.ID, created
+		}
+	}
+	return latest
+}
+
+// backupExpiresAt adds expireAfter to the backup's creation time. ok is false
+// when either is empty or malformed.
+func backupExpiresAt(createdIn, expireAfter string) (time.Time, bool) {
+	if createdIn == "" || expireAfter == "" {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(time.RFC3339, createdIn)
+	if err != nil {
+		return time.Time{}, false
+	}
+	age, err := parseBackupAge(expireAfter)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created.Add(age), true
+}
+
+// parseBackupAge parses a Go duration that may start with a whole number of
+// days, e.g. "30d", "1d12h" or "36h".
+func parseBackupAge(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.IndexByte(s, 'd'); i > 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[i+1:]
+		if s == "" {
+			return days, nil
+		}
+	}
+	rest, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + rest, nil
+}
+
+func validateBackupAge(v interface{}, k string) ([]string, []error) {
+	age, err := parseBackupAge(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	if age <= 0 {
+		return nil, []error{fmt.Errorf("%s must be positive, got %q", k, v)}
+	}
+	return nil, nil
+}
+
 // Waiters
 
 func waitBackupState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
@@ -0,0 +1,357 @@
+package clo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDbaasBackupExport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Copy a dbaas backup out of the cloud: the backup is streamed from its download URL to a " +
+			"local file or an object in an S3-compatible bucket, its size is checked against the backup's and " +
+			"its SHA-256 is recorded. The copy is made again only when `backup_id` changes, or when the copy " +
+			"goes missing. Destroying the resource keeps the copy unless `delete_on_destroy` is set.",
+		ReadContext:   resourceDbaasBackupExportRead,
+		CreateContext: resourceDbaasBackupExportCreate,
+		UpdateContext: resourceDbaasBackupExportUpdate,
+		DeleteContext: resourceDbaasBackupExportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"backup_id": {
+				Description: "ID of the backup to export",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"local_path": {
+				Description:  "Path of the local file to write the backup to. Missing parent directories are created.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"local_path", "s3"},
+			},
+			"s3": {
+				Description: "S3-compatible bucket to upload the backup to",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": {
+							Description:  "URL of the S3 endpoint, e.g. `https://s3.example.com`",
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"region": {
+							Description: "Region to sign requests for. Defaults to `" + s3api.DefaultRegion + "`, which S3-compatible services without regions accept.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     s3api.DefaultRegion,
+						},
+						"bucket": {
+							Description: "Name of an existing bucket",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"key": {
+							Description: "Object key to store the backup under",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"access_key": {
+							Description: "Access key for the bucket. Rotating it does not upload the backup again.",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"secret_key": {
+							Description: "Secret key for the bucket",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"delete_on_destroy": {
+				Description: "Delete the copy when the resource is destroyed. A replacement that writes to the same " +
+					"location then removes the old copy before the new one is written. Defaults to false.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"size": {
+				Description: "Size of the exported copy in bytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"sha256": {
+				Description: "Hex-encoded SHA-256 of the exported copy",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDbaasBackupExportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id := d.Get("backup_id").(string)
+	target, err := backupExportTargetFor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitBackupState(ctx, id, cli, []string{buildBackup}, []string{availableBackup}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	backup, err := cli.GetBackup(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, err := cli.OpenBackupDownload(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer body.Close()
+
+	sum, err := exportBackup(ctx, target, body, int64(backup.Size))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("exporting backup %s: %w", id, err))
+	}
+	d.SetId(id)
+	if e := d.Set("size", backup.Size); e != nil {
+		return diag.FromErr(e)
+	}
+	if e := d.Set("sha256", sum); e != nil {
+		return diag.FromErr(e)
+	}
+	return resourceDbaasBackupExportRead(ctx, d, m)
+}
+
+func resourceDbaasBackupExportRead(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	target, err := backupExportTargetFor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The copy is only checked for presence and size; hashing it again on every
+	// refresh would mean reading the whole backup.
+	size, err := target.stat(ctx)
+	if errors.Is(err, errExportMissing) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if size != int64(d.Get("size").(int)) {
+		d.SetId("")
+	}
+	return nil
+}
+
+// resourceDbaasBackupExportUpdate only records changed credentials, region or
+// delete_on_destroy; everything that names the copy forces a new one.
+func resourceDbaasBackupExportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceDbaasBackupExportRead(ctx, d, m)
+}
+
+// resourceDbaasBackupExportDelete keeps the copy by default: it is the offsite
+// copy the resource exists for, and a replacement may not manage to write a
+// new one.
+func resourceDbaasBackupExportDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if !d.Get("delete_on_destroy").(bool) {
+		return nil
+	}
+	target, err := backupExportTargetFor(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := target.remove(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// errExportMissing is returned by backupExportTarget.stat when there is no copy.
+var errExportMissing = errors.New("exported copy is missing")
+
+// backupExportTarget is where an exported backup is written.
+type backupExportTarget interface {
+	// write stores r, which yields exactly size bytes or fails.
+	write(ctx context.Context, r io.Reader, size int64) error
+	stat(ctx context.Context) (int64, error)
+	remove(ctx context.Context) error
+}
+
+func backupExportTargetFor(d *schema.ResourceData) (backupExportTarget, error) {
+	if p := d.Get("local_path").(string); p != "" {
+		return localExportTarget{path: p}, nil
+	}
+	l := d.Get("s3").([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil, errors.New("one of local_path or s3 must be set")
+	}
+	ms := l[0].(map[string]interface{})
+	c, err := s3api.New(ms["endpoint"].(string), ms["region"].(string), ms["access_key"].(string), ms["secret_key"].(string))
+	if err != nil {
+		return nil, err
+	}
+	return s3ExportTarget{cli: c, bucket: ms["bucket"].(string), key: ms["key"].(string)}, nil
+}
+
+// exportBackup copies body to target, checking that it is exactly size bytes
+// long, and returns its hex SHA-256. A copy of the wrong size is not kept.
+func exportBackup(ctx context.Context, target backupExportTarget, body io.Reader, size int64) (string, error) {
+	r := &sizedReader{r: body, size: size, hash: sha256.New()}
+	if err := target.write(ctx, r, size); err != nil {
+		// A failed write leaves no new copy behind; report the size mismatch
+		// rather than how the target noticed it.
+		if r.err != nil {
+			return "", r.err
+		}
+		return "", err
+	}
+	// The target stopped reading early and still stored what it got.
+	if r.n != size {
+		_ = target.remove(ctx)
+		return "", fmt.Errorf("wrote %d of %d bytes", r.n, size)
+	}
+	return hex.EncodeToString(r.hash.Sum(nil)), nil
+}
+
+// sizedReader hashes what it reads and fails when the stream is shorter or
+// longer than size.
+type sizedReader struct {
+	r    io.Reader
+	size int64
+	n    int64
+	hash hash.Hash
+	err  error
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.n >= s.size {
+		var probe [1]byte
+		if k, _ := io.ReadFull(s.r, probe[:]); k > 0 {
+			s.err = fmt.Errorf("backup is larger than its reported size of %d bytes", s.size)
+			return 0, s.err
+		}
+		return 0, io.EOF
+	}
+	if rest := s.size - s.n; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	k, err := s.r.Read(p)
+	s.hash.Write(p[:k])
+	s.n += int64(k)
+	if err == io.EOF && s.n < s.size {
+		s.err = fmt.Errorf("backup stream ended after %d of %d bytes", s.n, s.size)
+		return k, s.err
+	}
+	return k, err
+}
+
+// localExportTarget writes to a temporary file next to path and renames it into
+// place once complete, so path never holds a partial copy.
+type localExportTarget struct {
+	path string
+}
+
+func (t localExportTarget) write(_ context.Context, r io.Reader, _ int64) error {
+	dir := filepath.Dir(t.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(t.path)+".*.part")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = io.Copy(f, r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, t.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
+
+func (t localExportTarget) stat(_ context.Context) (int64, error) {
+	fi, err := os.Stat(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, errExportMissing
+	}
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
+func (t localExportTarget) remove(_ context.Context) error {
+	if err := os.Remove(t.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// s3ExportTarget streams the backup into one object.
+type s3ExportTarget struct {
+	cli    *s3api.Client
+	bucket string
+	key    string
+}
+
+func (t s3ExportTarget) write(ctx context.Context, r io.Reader, size int64) error {
+	_, err := t.cli.PutObject(ctx, t.bucket, t.key, r, size, s3api.PutObjectOptions{ContentType: "application/octet-stream"})
+	return err
+}
+
+func (t s3ExportTarget) stat(ctx context.Context) (int64, error) {
+	info, err := t.cli.HeadObject(ctx, t.bucket, t.key)
+	if s3api.IsNotFound(err) {
+		return 0, errExportMissing
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+func (t s3ExportTarget) remove(ctx context.Context) error {
+	if err := t.cli.DeleteObject(ctx, t.bucket, t.key); err != nil && !s3api.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
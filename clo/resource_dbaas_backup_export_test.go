@@ -0,0 +1,130 @@
+package clo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExportBackupLocal(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "exports", "backup.tar")
+	target := localExportTarget{path: path}
+
+	sum, err := exportBackup(ctx, target, strings.NewReader("backup-data"), 11)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := sha256.Sum256([]byte("backup-data")); sum != hex.EncodeToString(want[:]) {
+		t.Errorf("sha256 = %s", sum)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "backup-data" {
+		t.Errorf("file content = %q", data)
+	}
+	if size, err := target.stat(ctx); err != nil || size != 11 {
+		t.Errorf("stat = %d, %v", size, err)
+	}
+
+	// A stream of the wrong size must not replace the good copy or leave
+	// partial files behind.
+	for _, body := range []string{"short", "backup-data-and-more"} {
+		if _, err := exportBackup(ctx, target, strings.NewReader(body), 11); err == nil {
+			t.Errorf("%q: expected a size mismatch error", body)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != "backup-data" {
+		t.Errorf("good copy was replaced: %q", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+
+	if err := target.remove(ctx); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := target.stat(ctx); err != errExportMissing {
+		t.Errorf("stat after remove = %v, want errExportMissing", err)
+	}
+	if err := target.remove(ctx); err != nil {
+		t.Errorf("removing a missing copy must succeed: %v", err)
+	}
+}
+
+func TestExportBackupS3(t *testing.T) {
+	ctx := context.Background()
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("offsite")
+	cli, err := s3api.New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := s3ExportTarget{cli: cli, bucket: "offsite", key: "db/backup.tar"}
+
+	localSum, err := exportBackup(ctx, localExportTarget{path: filepath.Join(t.TempDir(), "b")}, strings.NewReader("backup-data"), 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := exportBackup(ctx, target, strings.NewReader("backup-data"), 11)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum != localSum {
+		t.Errorf("sha256 differs between targets: %s vs %s", sum, localSum)
+	}
+	if o := srv.Object("offsite", "db/backup.tar"); o == nil || string(o.Data) != "backup-data" {
+		t.Errorf("object not stored: %+v", o)
+	}
+	if size, err := target.stat(ctx); err != nil || size != 11 {
+		t.Errorf("stat = %d, %v", size, err)
+	}
+
+	if _, err := exportBackup(ctx, s3ExportTarget{cli: cli, bucket: "offsite", key: "short"}, strings.NewReader("short"), 11); err == nil {
+		t.Error("expected a size mismatch error")
+	}
+	if srv.Object("offsite", "short") != nil {
+		t.Error("a short upload must not be stored")
+	}
+
+	if err := target.remove(ctx); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := target.stat(ctx); err != errExportMissing {
+		t.Errorf("stat after remove = %v, want errExportMissing", err)
+	}
+}
+
+func TestDbaasBackupExportDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	if err := os.WriteFile(path, []byte("backup-data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceDbaasBackupExport().Schema, map[string]interface{}{
+		"backup_id":  "b-1",
+		"local_path": path,
+	})
+	if diags := resourceDbaasBackupExportDelete(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("the copy should be kept by default: %v", err)
+	}
+
+	if err := d.Set("delete_on_destroy", true); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceDbaasBackupExportDelete(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("delete_on_destroy should remove the copy, stat = %v", err)
+	}
+}
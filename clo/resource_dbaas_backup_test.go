@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -87,3 +88,90 @@ func testAccCheckDbaasBackupDestroy(st *terraform.State) error {
 	}
 	return nil
 }
+
+func TestParseBackupAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30d":   30 * 24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"36h":   36 * time.Hour,
+		"90m":   90 * time.Minute,
+	}
+	for in, want := range cases {
+		got, err := parseBackupAge(in)
+		if err != nil || got != want {
+			t.Errorf("parseBackupAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "1.5d", "30days", "forever"} {
+		if _, err := parseBackupAge(in); err == nil {
+			t.Errorf("parseBackupAge(%q) succeeded, want an error", in)
+		}
+	}
+	if _, errs := validateBackupAge("-1d", "expire_after"); len(errs) == 0 {
+		t.Error("a negative age must be rejected")
+	}
+}
+
+func TestLatestBackupID(t *testing.T) {
+	backups := []cloapi.Backup{
+		{ID: "old", ClusterID: "cl-1", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-10-01T00:00:00Z"},
+		{ID: "new", ClusterID: "cl-1", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-10-02T00:00:00Z"},
+		{ID: "building", ClusterID: "cl-1", Type: fullBackup, Status: buildBackup, CreatedIn: "2026-10-03T00:00:00Z"},
+		{ID: "partial", ClusterID: "cl-1", Type: "PARTIAL", Status: availableBackup, CreatedIn: "2026-10-04T00:00:00Z"},
+		{ID: "other", ClusterID: "cl-2", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-10-05T00:00:00Z"},
+	}
+	if got := latestBackupID(backups, "cl-1", fullBackup); got != "new" {
+		t.Errorf("latest FULL = %q, want new", got)
+	}
+	if got := latestBackupID(backups, "cl-1", "PARTIAL"); got != "partial" {
+		t.Errorf("latest PARTIAL = %q, want partial", got)
+	}
+	if got := latestBackupID(backups, "cl-3", fullBackup); got != "" {
+		t.Errorf("latest for a cluster without backups = %q", got)
+	}
+}
+
+func TestDbaasBackupExpiryDiff(t *testing.T) {
+	state := func(createdIn string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "bk-1",
+			Attributes: map[string]string{
+				"id":                        "bk-1",
+				"cluster_id":                "cl-1",
+				"name":                      "pre-migration",
+				"force_delete":              "false",
+				"prevent_destroy_if_latest": "false",
+				"expire_after":              "7d",
+				"expired":                   "false",
+				"status":                    availableBackup,
+				"created_in":                createdIn,
+			},
+		}
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":   "cl-1",
+		"name":         "pre-migration",
+		"expire_after": "7d",
+	})
+
+	fresh := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	diff, err := resourceDbaasBackup().Diff(context.Background(), state(fresh), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && diff.Attributes["expired"] != nil {
+		t.Errorf("a fresh backup must not expire: %v", diff.Attributes["expired"])
+	}
+
+	old := time.Now().Add(-8 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	diff, err = resourceDbaasBackup().Diff(context.Background(), state(old), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil || diff.Attributes["expired"] == nil || diff.Attributes["expired"].New != "true" {
+		t.Fatalf("an old backup must be planned to expire, got %v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Error("expiry must not replace the backup")
+	}
+}
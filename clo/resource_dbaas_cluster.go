@@ -2,12 +2,18 @@ package clo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Dbaas cluster lifecycle statuses, per the cloud_dbaas model. status spans
@@ -30,6 +36,18 @@ const (
 	switchOnCluster = "ON"
 )
 
+// Dbaas node roles and statuses, per the cloud_dbaas_node model. A node in ERROR
+// is left out of every pending set so the node waiters fail on it.
+const (
+	primaryNode = "MASTER"
+	replicaNode = "REPLICA"
+
+	buildNode    = "BUILD"
+	activeNode   = "ACTIVE"
+	updatingNode = "UPDATING"
+	deletingNode = "DELETING"
+)
+
 func resourceDbaasCluster() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manage a managed-database (dbaas) cluster in the project. `enabled` toggles the cluster's power state (start/stop). Databases are managed with the separate `clo_dbaas_database` resource.",
@@ -37,6 +55,7 @@ func resourceDbaasCluster() *schema.Resource {
 		CreateContext: resourceDbaasClusterCreate,
 		UpdateContext: resourceDbaasClusterUpdate,
 		DeleteContext: resourceDbaasClusterDelete,
+		CustomizeDiff: customdiff.All(validateDbaasClusterPointInTime, planDbaasClusterUpgrade, planDbaasClusterReplicas, planDbaasClusterBackupSchedule),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -56,11 +75,10 @@ func resourceDbaasCluster() *schema.Resource {
 				Required:    true,
 			},
 			"datastore_id": {
-				Description: "ID of the datastore (database engine + version) for the cluster. Resolve it with the `clo_dbaas_datastores` data source.",
+				Description: "ID of the datastore (database engine + version) for the cluster. Resolve it with the `clo_dbaas_datastores` data source. Moving to a newer version of the same engine and major version upgrades the cluster in place, after taking a FULL backup, and needs the provider's `preview_endpoints`; any other change replaces the cluster. A change must be known at plan time.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"storage_size": {
 				Description: "Size of the data storage in GiB. Can only be grown, never shrunk.",
@@ -115,10 +133,41 @@ func resourceDbaasCluster() *schema.Resource {
 				},
 			},
 			"restore_from_backup_id": {
-				Description: "ID of a backup to restore into the new cluster. Mutually exclusive with an initial database.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
+				Description:   "ID of a backup to restore into the new cluster. Mutually exclusive with an initial database.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"restore_point_in_time"},
+			},
+			"restore_point_in_time": {
+				Description:   "Restore another cluster's data as it was at a given moment into the new cluster. The latest FULL backup taken at or before `timestamp` is restored and replayed up to it; the timestamp must fall between the source cluster's oldest available FULL backup and now. Needs the provider's `preview_endpoints`.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"restore_from_backup_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_cluster_id": {
+							Description: "ID of the cluster whose backups are restored",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"timestamp": {
+							Description:  "RFC3339 moment to restore to, e.g. `2026-10-01T12:30:00Z`",
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"backup_id": {
+							Description: "ID of the FULL backup the restore started from",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
 			},
 			"enabled": {
 				Description: "Whether the cluster is powered on. Defaults to true.",
@@ -126,12 +175,35 @@ func resourceDbaasCluster() *schema.Resource {
 				Optional:    true,
 				Default:     true,
 			},
+			"replicas": {
+				Description:  "Number of read replica nodes besides the primary. Replicas are added or removed in place; the most recently added ones are removed first. Defaults to the API's choice. Setting it needs the provider's `preview_endpoints`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"backup_enabled": {
 				Description: "Whether scheduled backups are enabled for the cluster. Defaults to true.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 			},
+			"backup_hour": {
+				Description: "UTC hour (0-23) at which the daily scheduled backup runs. Defaults to the API's choice. Setting it needs " +
+					"the provider's `preview_endpoints`, and `backup_retention` too while the cluster has no backup schedule.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 23),
+			},
+			"backup_retention": {
+				Description: "Number of scheduled backups to keep; older ones are removed. With daily backups this is the retention in days. " +
+					"Defaults to the API's choice; reads as 0 while the cluster has no backup schedule. Setting it needs the provider's `preview_endpoints`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"id": {
 				Description: "ID of the cluster",
 				Type:        schema.TypeString,
@@ -187,11 +259,6 @@ func resourceDbaasCluster() *schema.Resource {
 				Type:        schema.TypeInt,
 				Computed:    true,
 			},
-			"backup_hour": {
-				Description: "UTC hour at which scheduled backups run",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
 			"created_in": {
 				Description: "Timestamp the cluster was created",
 				Type:        schema.TypeString,
@@ -203,7 +270,23 @@ func resourceDbaasCluster() *schema.Resource {
 
 func resourceDbaasClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
-	id, err := cli.CreateCluster(ctx, d.Get("project_id").(string), buildClusterCreateParams(d))
+	params := buildClusterCreateParams(d)
+	if pit := d.Get("restore_point_in_time").([]interface{}); len(pit) > 0 && pit[0] != nil {
+		mpit := pit[0].(map[string]interface{})
+		// Resolve the base backup again at apply time: backups may have been
+		// taken or rotated out since the plan.
+		backup, at, err := resolvePointInTime(ctx, cli, mpit["source_cluster_id"].(string), mpit["timestamp"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		params.RestoreFromBackup = backup.ID
+		params.RestoreTime = at.UTC().Format(time.RFC3339)
+		mpit["backup_id"] = backup.ID
+		if err := d.Set("restore_point_in_time", []interface{}{mpit}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	id, err := cli.CreateCluster(ctx, d.Get("project_id").(string), params)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -213,6 +296,12 @@ func resourceDbaasClusterCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if configured(d, "replicas") {
+		if err := scaleClusterReplicas(ctx, id, cli, d.Get("replicas").(int), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// A freshly created cluster comes up running; only act if the user asked for it stopped.
 	if !d.Get("enabled").(bool) {
 		if err := cli.StopCluster(ctx, id); err != nil {
@@ -230,6 +319,12 @@ func resourceDbaasClusterCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
+	if configured(d, "backup_hour") || configured(d, "backup_retention") {
+		if err := updateClusterBackupSchedule(ctx, d, cli); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDbaasClusterRead(ctx, d, m)
 }
 
@@ -259,8 +354,8 @@ func resourceDbaasClusterRead(ctx context.Context, d *schema.ResourceData, m int
 		"databases_count":   c.DatabasesCount,
 		"external_address":  c.ExternalAddress,
 		"internal_address":  c.InternalAddress,
-		"backup_hour":       c.BackupHour,
 		"backup_enabled":    c.BackupEnabled,
+		"backup_hour":       c.BackupHour,
 		"created_in":        c.CreatedIn,
 		"enabled":           c.SwitchStatus == switchOnCluster,
 		"flavor":            flattenClusterFlavor(c),
@@ -270,6 +365,29 @@ func resourceDbaasClusterRead(ctx context.Context, d *schema.ResourceData, m int
 			return diag.FromErr(e)
 		}
 	}
+
+	nodes, err := cli.ListNodes(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if e := d.Set("replicas", len(clusterReplicas(nodes))); e != nil {
+		return diag.FromErr(e)
+	}
+
+	// The cluster reports the backup hour itself; only the retention needs the
+	// schedule, which a cluster that never had one configured does not have and
+	// which cannot be read while preview endpoints are disabled.
+	retention := 0
+	sched, err := cli.GetClusterBackupSchedule(ctx, d.Id())
+	switch {
+	case err == nil:
+		retention = sched.Retention
+	case !cloapi.IsNotFound(err) && !errors.Is(err, cloapi.ErrPreviewDisabled):
+		return diag.FromErr(err)
+	}
+	if e := d.Set("backup_retention", retention); e != nil {
+		return diag.FromErr(e)
+	}
 	return nil
 }
 
@@ -277,6 +395,12 @@ func resourceDbaasClusterUpdate(ctx context.Context, d *schema.ResourceData, m i
 	cli := m.(*providerMeta).v3
 	id := d.Id()
 
+	if d.HasChange("datastore_id") {
+		if err := upgradeClusterDatastore(ctx, d, cli); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	changed := false
 	if d.HasChange("name") {
 		if err := cli.RenameCluster(ctx, id, d.Get("name").(string)); err != nil {
@@ -310,6 +434,12 @@ func resourceDbaasClusterUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
+	if d.HasChange("replicas") {
+		if err := scaleClusterReplicas(ctx, id, cli, d.Get("replicas").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("enabled") {
 		enabled := d.Get("enabled").(bool)
 		if enabled {
@@ -338,6 +468,12 @@ func resourceDbaasClusterUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
+	if d.HasChanges("backup_hour", "backup_retention") {
+		if err := updateClusterBackupSchedule(ctx, d, cli); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDbaasClusterRead(ctx, d, m)
 }
 
@@ -383,6 +519,343 @@ func buildClusterCreateParams(d *schema.ResourceData) cloapi.ClusterCreateParams
 	return p
 }
 
+// updateClusterBackupSchedule sends backup_hour and backup_retention together.
+// A value left out of the config is taken from the cluster (the hour) or its
+// current schedule (the retention) so only the configured one changes.
+func updateClusterBackupSchedule(ctx context.Context, d *schema.ResourceData, cli *cloapi.Client) error {
+	hour, retention := d.Get("backup_hour"), d.Get("backup_retention")
+	if !configured(d, "backup_hour") {
+		c, err := cli.GetCluster(ctx, d.Id())
+		if err != nil {
+			return err
+		}
+		hour = c.BackupHour
+	}
+	if !configured(d, "backup_retention") {
+		sched, err := cli.GetClusterBackupSchedule(ctx, d.Id())
+		if cloapi.IsNotFound(err) {
+			return errors.New("backup_retention must be set: the cluster has no backup schedule to keep it from")
+		}
+		if err != nil {
+			return err
+		}
+		retention = sched.Retention
+	}
+	return cli.UpdateClusterBackupSchedule(ctx, d.Id(), hour.(int), retention.(int))
+}
+
+// configured reports whether a top-level attribute is set in the config. Unlike
+// GetOk it tells an explicit zero (e.g. backup_hour = 0) from an unset value.
+func configured(d *schema.ResourceData, key string) bool {
+	v := d.GetRawConfig()
+	if v.IsNull() || !v.IsKnown() {
+		return false
+	}
+	return !v.GetAttr(key).IsNull()
+}
+
+// configuredInPlan is the CustomizeDiff counterpart of configured.
+func configuredInPlan(d *schema.ResourceDiff, key string) bool {
+	v := d.GetRawConfig()
+	if v.IsNull() || !v.IsKnown() {
+		return false
+	}
+	return !v.GetAttr(key).IsNull()
+}
+
+// planDbaasClusterReplicas refuses configured replicas while preview endpoints
+// are disabled: they are added or removed once the cluster exists.
+func planDbaasClusterReplicas(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !configuredInPlan(d, "replicas") || (d.Id() != "" && !d.HasChange("replicas")) {
+		return nil
+	}
+	return requirePreview(m, "replicas")
+}
+
+// planDbaasClusterBackupSchedule checks at plan time what the schedule update
+// after create or on change needs: the preview endpoints, and a retention to
+// send with the hour when the cluster has no schedule to keep one from.
+func planDbaasClusterBackupSchedule(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	changing := func(key string) bool {
+		return configuredInPlan(d, key) && (d.Id() == "" || d.HasChange(key))
+	}
+	if !changing("backup_hour") && !changing("backup_retention") {
+		return nil
+	}
+	if err := requirePreview(m, "backup_hour and backup_retention"); err != nil {
+		return err
+	}
+	if old, _ := d.GetChange("backup_retention"); !configuredInPlan(d, "backup_retention") && old.(int) == 0 {
+		return errors.New("backup_retention must be set along with backup_hour: the cluster has no backup schedule to keep it from")
+	}
+	return nil
+}
+
+// validateDbaasClusterPointInTime checks at plan time that restore_point_in_time
+// can be created, which needs the preview endpoints, and falls inside the source
+// cluster's restore window. The window check is skipped while the block is
+// still unknown, e.g. when the source cluster is created in the same apply.
+func validateDbaasClusterPointInTime(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if m == nil || (d.Id() != "" && !d.HasChange("restore_point_in_time")) {
+		return nil
+	}
+	if d.Get("restore_point_in_time.#").(int) == 0 {
+		return nil
+	}
+	if err := requirePreview(m, "restore_point_in_time"); err != nil {
+		return err
+	}
+	if !d.NewValueKnown("restore_point_in_time.0.source_cluster_id") || !d.NewValueKnown("restore_point_in_time.0.timestamp") {
+		return nil
+	}
+	sourceID := d.Get("restore_point_in_time.0.source_cluster_id").(string)
+	ts := d.Get("restore_point_in_time.0.timestamp").(string)
+	if sourceID == "" || ts == "" {
+		return nil
+	}
+	_, _, err := resolvePointInTime(ctx, m.(*providerMeta).v3, sourceID, ts)
+	return err
+}
+
+// resolvePointInTime looks up the source cluster's backups and picks the one to
+// replay to ts.
+func resolvePointInTime(ctx context.Context, cli *cloapi.Client, sourceID, ts string) (*cloapi.Backup, time.Time, error) {
+	at, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("restore_point_in_time.0.timestamp: %w", err)
+	}
+	source, err := cli.GetCluster(ctx, sourceID)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("restore_point_in_time: source cluster %s: %w", sourceID, err)
+	}
+	backups, err := cli.ListBackups(ctx, source.Project)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	b, err := pickPointInTimeBackup(backups, sourceID, at, time.Now())
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("restore_point_in_time: %w", err)
+	}
+	return b, at, nil
+}
+
+// pickPointInTimeBackup returns the latest available FULL backup of the cluster
+// taken at or before at. The restore window runs from the oldest such backup to
+// now; a moment outside it is an error naming the window.
+func pickPointInTimeBackup(backups []cloapi.Backup, clusterID string, at, now time.Time) (*cloapi.Backup, error) {
+	var best, oldest *cloapi.Backup
+	var bestAt, oldestAt time.Time
+	for i := range backups {
+		b := &backups[i]
+		if b.ClusterID != clusterID || b.Type != fullBackup || b.Status != availableBackup {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, b.CreatedIn)
+		if err != nil {
+			continue
+		}
+		if oldest == nil || created.Before(oldestAt) {
+			oldest, oldestAt = b, created
+		}
+		if !created.After(at) && (best == nil || created.After(bestAt)) {
+			best, bestAt = b, created
+		}
+	}
+	if oldest == nil {
+		return nil, fmt.Errorf("cluster %s has no available FULL backup to restore from", clusterID)
+	}
+	window := fmt.Sprintf("%s to %s", oldestAt.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))
+	if at.After(now) {
+		return nil, fmt.Errorf("%s is in the future; cluster %s can be restored from %s", at.Format(time.RFC3339), clusterID, window)
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%s is before the oldest FULL backup of cluster %s; it can be restored from %s", at.Format(time.RFC3339), clusterID, window)
+	}
+	return best, nil
+}
+
+// planDbaasClusterUpgrade lets a datastore_id change go in place when it is an
+// upgrade within the engine's major version, and forces replacement otherwise.
+// A new datastore that is unknown until apply cannot be compared, and the SDK
+// cannot force replacement on an unknown value, so such a plan is refused.
+func planDbaasClusterUpgrade(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("datastore_id") {
+		return nil
+	}
+	o, n := d.GetChange("datastore_id")
+	if o.(string) == "" {
+		return nil
+	}
+	if !d.NewValueKnown("datastore_id") {
+		return errors.New("datastore_id: the new datastore is not known until apply, so the plan cannot tell an in-place upgrade from a replacement; change it to a value known at plan time")
+	}
+	stores, err := m.(*providerMeta).v3.ListDatastores(ctx, d.Get("project_id").(string))
+	if err != nil {
+		return err
+	}
+	to := findDatastore(stores, n.(string))
+	if to == nil {
+		return fmt.Errorf("datastore_id: datastore %s is not offered in project %s", n, d.Get("project_id"))
+	}
+	// A datastore that is no longer offered cannot be compared; replace to be safe.
+	from := findDatastore(stores, o.(string))
+	if from == nil || datastoreUpgradeInPlace(*from, *to) != nil {
+		return d.ForceNew("datastore_id")
+	}
+	// Never fall back to replacing, which would destroy the cluster's data.
+	return requirePreview(m, "datastore_id: upgrading in place")
+}
+
+// upgradeClusterDatastore takes a FULL backup of the cluster and then upgrades
+// it to the configured datastore, waiting through BACKUP and UPDATING.
+func upgradeClusterDatastore(ctx context.Context, d *schema.ResourceData, cli *cloapi.Client) error {
+	id, timeout := d.Id(), d.Timeout(schema.TimeoutUpdate)
+	o, n := d.GetChange("datastore_id")
+	stores, err := cli.ListDatastores(ctx, d.Get("project_id").(string))
+	if err != nil {
+		return err
+	}
+	from, to := findDatastore(stores, o.(string)), findDatastore(stores, n.(string))
+	if from == nil || to == nil {
+		return fmt.Errorf("datastore_id: cannot upgrade from %s to %s: datastore not offered in the project", o, n)
+	}
+	if err := datastoreUpgradeInPlace(*from, *to); err != nil {
+		return fmt.Errorf("datastore_id: %w", err)
+	}
+
+	backupID, err := cli.CreateClusterBackup(ctx, id, "pre-upgrade-"+to.Version)
+	if err != nil {
+		return fmt.Errorf("pre-upgrade backup: %w", err)
+	}
+	if err := waitBackupState(ctx, backupID, cli, []string{buildBackup}, []string{availableBackup}, timeout); err != nil {
+		return fmt.Errorf("pre-upgrade backup %s: %w", backupID, err)
+	}
+	if err := waitClusterState(ctx, id, cli, []string{backupCluster}, []string{activeCluster, stoppedCluster}, timeout); err != nil {
+		return err
+	}
+
+	if err := cli.UpgradeCluster(ctx, id, to.ID); err != nil {
+		return err
+	}
+	return waitClusterSettled(ctx, id, cli, timeout)
+}
+
+func findDatastore(stores []cloapi.Datastore, id string) *cloapi.Datastore {
+	for i := range stores {
+		if stores[i].ID == id {
+			return &stores[i]
+		}
+	}
+	return nil
+}
+
+// datastoreUpgradeInPlace reports why moving from one datastore to another
+// cannot be done in place, or nil when it is a same-engine upgrade within the
+// major version. PostgreSQL numbers its major versions with one component
+// (14, 15); the other engines use two (MySQL 8.0, Redis 7.2).
+func datastoreUpgradeInPlace(from, to cloapi.Datastore) error {
+	if !strings.EqualFold(from.Name, to.Name) {
+		return fmt.Errorf("changing the engine from %s to %s requires a new cluster", from.Name, to.Name)
+	}
+	majorParts := 2
+	if strings.Contains(strings.ToLower(from.Name), "postgres") {
+		majorParts = 1
+	}
+	fv, tv := versionParts(from.Version), versionParts(to.Version)
+	if len(fv) < majorParts || len(tv) < majorParts {
+		return fmt.Errorf("cannot compare %s versions %q and %q", from.Name, from.Version, to.Version)
+	}
+	if compareVersions(fv[:majorParts], tv[:majorParts]) != 0 {
+		return fmt.Errorf("upgrading %s from %s to %s crosses a major version and requires a new cluster", from.Name, from.Version, to.Version)
+	}
+	if compareVersions(fv, tv) > 0 {
+		return fmt.Errorf("downgrading %s from %s to %s is not supported", from.Name, from.Version, to.Version)
+	}
+	return nil
+}
+
+// versionParts splits a dotted version into its leading numeric components,
+// ignoring any suffix such as "-1" or "ubuntu".
+func versionParts(v string) []int {
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		end := 0
+		for end < len(p) && p[end] >= '0' && p[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(p[:end])
+		parts = append(parts, n)
+		if end < len(p) {
+			break
+		}
+	}
+	return parts
+}
+
+// compareVersions compares two version component lists, treating missing
+// trailing components as zero.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// scaleClusterReplicas adds or removes replica nodes until the cluster has want
+// of them, then waits for every node to be ACTIVE.
+func scaleClusterReplicas(ctx context.Context, id string, cli *cloapi.Client, want int, timeout time.Duration) error {
+	nodes, err := cli.ListNodes(ctx, id)
+	if err != nil {
+		return err
+	}
+	replicas := clusterReplicas(nodes)
+	for i := len(replicas); i < want; i++ {
+		if _, err := cli.AddClusterReplica(ctx, id); err != nil {
+			return err
+		}
+	}
+	if len(replicas) > want {
+		for _, n := range replicas[want:] {
+			if err := cli.DeleteClusterNode(ctx, n.ID); err != nil && !cloapi.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return waitClusterNodesActive(ctx, id, cli, len(nodes)-len(replicas)+want, timeout)
+}
+
+// clusterReplicas returns the replica nodes, oldest first, so that trimming the
+// tail removes the most recently added ones.
+func clusterReplicas(nodes []cloapi.Node) []cloapi.Node {
+	var out []cloapi.Node
+	for _, n := range nodes {
+		if n.Role == replicaNode {
+			out = append(out, n)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339, out[i].CreatedIn)
+		tj, _ := time.Parse(time.RFC3339, out[j].CreatedIn)
+		return ti.Before(tj)
+	})
+	return out
+}
+
 func flattenClusterFlavor(c *cloapi.Cluster) []interface{} {
 	return []interface{}{map[string]interface{}{
 		"vcpus": c.FlavorVcpus,
@@ -419,6 +892,37 @@ func waitClusterSettled(ctx context.Context, id string, cli *cloapi.Client, time
 	return waitClusterState(ctx, id, cli, []string{updatingCluster}, []string{activeCluster, stoppedCluster}, timeout)
 }
 
+// waitClusterNodesActive waits until the cluster has exactly count nodes and all
+// of them are ACTIVE. A node in any other status is reported as the cluster's
+// node state, so a failed node ends the wait with an error.
+func waitClusterNodesActive(ctx context.Context, id string, cli *cloapi.Client, count int, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{buildNode, updatingNode, deletingNode}, []string{activeNode}, func() (interface{}, string, error) {
+		nodes, err := cli.ListNodes(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return nodes, clusterNodesState(nodes, count), nil
+	})
+}
+
+// clusterNodesState folds the node statuses into one: the first status that is
+// not ACTIVE, else BUILD or DELETING while the node count has not reached count
+// yet, else ACTIVE.
+func clusterNodesState(nodes []cloapi.Node, count int) string {
+	for _, n := range nodes {
+		if n.Status != activeNode {
+			return n.Status
+		}
+	}
+	switch {
+	case len(nodes) < count:
+		return buildNode
+	case len(nodes) > count:
+		return deletingNode
+	}
+	return activeNode
+}
+
 func waitClusterDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
 	pending := []string{creatingCluster, activeCluster, stoppedCluster, startingCluster, stoppingCluster, updatingCluster, backupCluster, restoreCluster, deletingCluster}
 	return waitForState(ctx, timeout, pending, []string{deletedCluster}, func() (interface{}, string, error) {
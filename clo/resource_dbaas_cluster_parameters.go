@@ -0,0 +1,426 @@
+package clo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configErrorCluster is the status a cluster lands in when the engine rejects
+// a configuration change. Unlike the other failure statuses it is waited for
+// explicitly, so a parameters change can report it (and roll back).
+const configErrorCluster = "CONFIG_ERROR"
+
+func resourceDbaasClusterParameters() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage configuration parameters of a dbaas cluster (e.g. `max_connections`, `shared_buffers`). " +
+			"Only the listed parameters are managed: each apply compares them with the cluster's live configuration " +
+			"and sends just the ones that differ. Parameters removed from the map, and all of them on destroy, are " +
+			"reset to the datastore default. Import with the cluster ID.",
+		ReadContext:   resourceDbaasClusterParametersRead,
+		CreateContext: resourceDbaasClusterParametersCreate,
+		UpdateContext: resourceDbaasClusterParametersUpdate,
+		DeleteContext: resourceDbaasClusterParametersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDbaasClusterParameters,
+		},
+		CustomizeDiff: validateDbaasClusterParameters,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the dbaas cluster to configure",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"parameters": {
+				Description: "Parameter name -> value. Values are written as strings and checked at plan time against the " +
+					"type of the parameter's datastore default: a number, a bool (`true`/`false`, `on`/`off`, `yes`/`no`, " +
+					"`1`/`0`), a list (JSON, e.g. `[\"a\",\"b\"]`, or comma-separated) or a string. Equivalent spellings " +
+					"(`1.0` and `1`, `on` and `true`) do not produce a diff.",
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: suppressEquivalentConfigValue,
+			},
+			"rollback_on_error": {
+				Description: "When the cluster rejects the new values (`CONFIG_ERROR`), restore the changed parameters to " +
+					"the cluster's last stable configuration before failing. Defaults to false.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Description: "Status of the cluster after the last change",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDbaasClusterParametersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	clusterID := d.Get("cluster_id").(string)
+	if err := applyClusterParameters(ctx, d, cli, clusterID, nil, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(clusterID)
+	return resourceDbaasClusterParametersRead(ctx, d, m)
+}
+
+func resourceDbaasClusterParametersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	c, err := cli.GetCluster(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cfg, err := cli.GetClusterConfig(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the managed keys are tracked; a managed key the cluster no longer
+	// reports drops out of state and shows up as a diff.
+	params := make(map[string]interface{})
+	for k := range d.Get("parameters").(map[string]interface{}) {
+		if v, ok := cfg.Current[k]; ok {
+			params[k] = stringifyConfigValue(v)
+		}
+	}
+	fields := map[string]interface{}{
+		"cluster_id": c.ID,
+		"parameters": params,
+		"status":     c.Status,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceDbaasClusterParametersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChange("parameters") {
+		o, n := d.GetChange("parameters")
+		var removed []string
+		for k := range o.(map[string]interface{}) {
+			if _, ok := n.(map[string]interface{})[k]; !ok {
+				removed = append(removed, k)
+			}
+		}
+		if err := applyClusterParameters(ctx, d, cli, d.Id(), removed, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			// Keep the previous parameters in state: they are what the
+			// cluster runs with after a failure or a rollback.
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+	return resourceDbaasClusterParametersRead(ctx, d, m)
+}
+
+func resourceDbaasClusterParametersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	cfg, err := cli.GetClusterConfig(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var keys []string
+	for k := range d.Get("parameters").(map[string]interface{}) {
+		keys = append(keys, k)
+	}
+	reset := clusterParameterDefaults(cfg, keys)
+	if len(reset) == 0 {
+		return nil
+	}
+	if err := cli.UpdateClusterConfig(ctx, d.Id(), reset); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := waitClusterConfigApplied(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func importDbaasClusterParameters(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if e := d.Set("cluster_id", d.Id()); e != nil {
+		return nil, e
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyClusterParameters sends the configured parameters that differ from the
+// cluster's live configuration, plus the defaults of the removed keys, and
+// waits for the cluster to take them. On CONFIG_ERROR the changed keys are
+// restored from LastStable when rollback_on_error is set.
+func applyClusterParameters(ctx context.Context, d *schema.ResourceData, cli *cloapi.Client, clusterID string, removed []string, timeout time.Duration) error {
+	cfg, err := cli.GetClusterConfig(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	changes, err := clusterParameterChanges(cfg, d.Get("parameters").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	for k, v := range clusterParameterDefaults(cfg, removed) {
+		changes[k] = v
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := cli.UpdateClusterConfig(ctx, clusterID, changes); err != nil {
+		return err
+	}
+	status, err := waitClusterConfigApplied(ctx, clusterID, cli, timeout)
+	if err != nil {
+		return err
+	}
+	if status != configErrorCluster {
+		return nil
+	}
+
+	keys := sortedKeys(changes)
+	if !d.Get("rollback_on_error").(bool) {
+		return fmt.Errorf("cluster %s rejected parameters %s and is in %s; fix the values or set rollback_on_error",
+			clusterID, strings.Join(keys, ", "), configErrorCluster)
+	}
+	rollback := make(map[string]interface{}, len(changes))
+	for _, k := range keys {
+		if v, ok := cfg.LastStable[k]; ok {
+			rollback[k] = v
+		} else if v, ok := cfg.Default[k]; ok {
+			rollback[k] = v
+		}
+	}
+	if err := cli.UpdateClusterConfig(ctx, clusterID, rollback); err != nil {
+		return fmt.Errorf("cluster %s rejected parameters %s; rolling back failed: %w", clusterID, strings.Join(keys, ", "), err)
+	}
+	status, err = waitClusterConfigApplied(ctx, clusterID, cli, timeout)
+	if err != nil {
+		return fmt.Errorf("cluster %s rejected parameters %s; rolling back failed: %w", clusterID, strings.Join(keys, ", "), err)
+	}
+	if status == configErrorCluster {
+		return fmt.Errorf("cluster %s rejected parameters %s and is still in %s after rolling back to the last stable configuration",
+			clusterID, strings.Join(keys, ", "), configErrorCluster)
+	}
+	return fmt.Errorf("cluster %s rejected parameters %s; rolled them back to the last stable configuration",
+		clusterID, strings.Join(keys, ", "))
+}
+
+// clusterParameterChanges returns the wanted parameters whose value differs
+// from the live configuration, converted to the parameter's type. Values that
+// do not fit their parameter are reported together, one line per key.
+func clusterParameterChanges(cfg *cloapi.ClusterConfig, wanted map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	var errs []error
+	for _, k := range sortedKeys(wanted) {
+		v, err := parseClusterParameter(cfg, k, wanted[k].(string))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cur, ok := cfg.Current[k]; ok && sameConfigValue(cur, v) {
+			continue
+		}
+		out[k] = v
+	}
+	return out, errors.Join(errs...)
+}
+
+// parseClusterParameter converts a configured value to the type of the
+// parameter's clusterParameterSample.
+func parseClusterParameter(cfg *cloapi.ClusterConfig, key, s string) (interface{}, error) {
+	sample, ok := clusterParameterSample(cfg, key)
+	if !ok {
+		return nil, fmt.Errorf("parameters.%s: not a parameter of this cluster's datastore", key)
+	}
+	v, err := parseConfigValue(s, sample)
+	if err != nil {
+		return nil, fmt.Errorf("parameters.%s: %w", key, err)
+	}
+	return v, nil
+}
+
+// validateDbaasClusterParameters checks every configured value against its
+// parameter's type before anything is sent, so a bad value fails the plan
+// instead of leaving the cluster in CONFIG_ERROR. It is skipped while the
+// cluster or the values are unknown (e.g. the cluster is created in the same
+// apply); the same check then runs at apply time.
+func validateDbaasClusterParameters(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("cluster_id") || !d.NewValueKnown("parameters") || m == nil {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("parameters") {
+		return nil
+	}
+	cfg, err := m.(*providerMeta).v3.GetClusterConfig(ctx, d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+	_, err = clusterParameterChanges(cfg, d.Get("parameters").(map[string]interface{}))
+	return err
+}
+
+// clusterParameterDefaults returns the datastore default of each key that has one.
+func clusterParameterDefaults(cfg *cloapi.ClusterConfig, keys []string) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, k := range keys {
+		if v, ok := cfg.Default[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// clusterParameterSample returns a value that shows the parameter's type: the
+// default, or the live value for parameters without one. ok is false when the
+// datastore has no such parameter.
+func clusterParameterSample(cfg *cloapi.ClusterConfig, key string) (interface{}, bool) {
+	if v, ok := cfg.Default[key]; ok && v != nil {
+		return v, true
+	}
+	v, ok := cfg.Current[key]
+	return v, ok
+}
+
+// parseConfigValue converts a configured string to the type of sample (a
+// value decoded from the API), the inverse of stringifyConfigValue.
+func parseConfigValue(s string, sample interface{}) (interface{}, error) {
+	switch sample.(type) {
+	case float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", s)
+		}
+		return f, nil
+	case bool:
+		b, ok := parseConfigBool(s)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool (true/false, on/off, yes/no, 1/0), got %q", s)
+		}
+		return b, nil
+	case []interface{}:
+		return parseConfigList(s)
+	}
+	return s, nil
+}
+
+func parseConfigBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "on", "yes", "1":
+		return true, true
+	case "false", "off", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// parseConfigList accepts a JSON list or a comma-separated list of strings.
+func parseConfigList(s string) ([]interface{}, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "[") {
+		var l []interface{}
+		if err := json.Unmarshal([]byte(s), &l); err != nil {
+			return nil, fmt.Errorf("expected a JSON list: %v", err)
+		}
+		return l, nil
+	}
+	l := []interface{}{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			l = append(l, item)
+		}
+	}
+	return l, nil
+}
+
+// sameConfigValue compares a live value with a parsed one. The API reports an
+// empty list parameter as null.
+func sameConfigValue(cur, v interface{}) bool {
+	if l, ok := v.([]interface{}); ok && len(l) == 0 {
+		if c, ok := cur.([]interface{}); cur == nil || (ok && len(c) == 0) {
+			return true
+		}
+	}
+	return reflect.DeepEqual(cur, v)
+}
+
+// suppressEquivalentConfigValue hides the diff between spellings of the same
+// value. The state holds the value as the API renders it, which tells its
+// type: `1.0` matches a state of `1`, `on` matches `true`, and `a, b` matches
+// `["a","b"]`.
+func suppressEquivalentConfigValue(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	if old == "" || new == "" || k == "parameters.%" {
+		return false
+	}
+	var sample interface{}
+	switch {
+	case old == "true" || old == "false":
+		sample = false
+	case strings.HasPrefix(old, "["):
+		sample = []interface{}{}
+	default:
+		if _, err := strconv.ParseFloat(old, 64); err != nil {
+			return false
+		}
+		sample = float64(0)
+	}
+	v, err := parseConfigValue(new, sample)
+	if err != nil {
+		return false
+	}
+	return stringifyConfigValue(v) == old
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Waiters
+
+// waitClusterConfigApplied waits for a configuration change to leave UPDATING
+// and returns the status it settled in, CONFIG_ERROR included.
+func waitClusterConfigApplied(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) (string, error) {
+	var status string
+	err := waitForState(ctx, timeout, []string{updatingCluster}, []string{activeCluster, stoppedCluster, configErrorCluster}, func() (interface{}, string, error) {
+		c, err := cli.GetCluster(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		status = c.Status
+		return c, c.Status, nil
+	})
+	return status, err
+}
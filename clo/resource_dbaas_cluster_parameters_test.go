@@ -0,0 +1,210 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const dbaasClusterParametersName = "params_1"
+
+func TestAccCloDbaasClusterParameters_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	addr := fmt.Sprintf("clo_dbaas_cluster_parameters.%s", dbaasClusterParametersName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDbaasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloDbaasClusterParametersConfig(100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(addr, "parameters.max_connections", "100"),
+					testAccCheckDbaasClusterParameter(addr, "max_connections", "100"),
+				),
+			},
+			{
+				Config: testAccCloDbaasClusterParametersConfig(150),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(addr, "parameters.max_connections", "150"),
+					resource.TestCheckResourceAttr(addr, "status", "ACTIVE"),
+					testAccCheckDbaasClusterParameter(addr, "max_connections", "150"),
+				),
+			},
+			{
+				ResourceName:            addr,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"parameters", "rollback_on_error"},
+			},
+		},
+	})
+}
+
+func TestClusterParameterChanges(t *testing.T) {
+	cfg := &cloapi.ClusterConfig{
+		Current: map[string]interface{}{
+			"max_connections":  float64(100),
+			"shared_buffers":   "128MB",
+			"autovacuum":       true,
+			"preload_libs":     []interface{}{"pg_stat_statements"},
+			"work_mem":         "4MB",
+			"undocumented_int": float64(7),
+			"empty_list":       nil,
+		},
+		Default: map[string]interface{}{
+			"max_connections": float64(100),
+			"shared_buffers":  "128MB",
+			"autovacuum":      true,
+			"preload_libs":    []interface{}{},
+			"work_mem":        "4MB",
+			"empty_list":      []interface{}{},
+		},
+	}
+
+	t.Run("only_changed_keys", func(t *testing.T) {
+		got, err := clusterParameterChanges(cfg, map[string]interface{}{
+			"max_connections":  "200",
+			"shared_buffers":   "128MB",
+			"autovacuum":       "off",
+			"preload_libs":     "pg_stat_statements, auto_explain",
+			"undocumented_int": "9",
+			"empty_list":       "",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := map[string]interface{}{
+			"max_connections":  float64(200),
+			"autovacuum":       false,
+			"preload_libs":     []interface{}{"pg_stat_statements", "auto_explain"},
+			"undocumented_int": float64(9),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("changes wrong:\n got %#v\nwant %#v", got, want)
+		}
+	})
+
+	t.Run("equivalent_spelling_is_no_change", func(t *testing.T) {
+		got, err := clusterParameterChanges(cfg, map[string]interface{}{
+			"max_connections": "100.0",
+			"autovacuum":      "on",
+			"preload_libs":    `["pg_stat_statements"]`,
+		})
+		if err != nil || len(got) != 0 {
+			t.Errorf("expected no changes, got %v (err %v)", got, err)
+		}
+	})
+
+	t.Run("per_key_errors", func(t *testing.T) {
+		_, err := clusterParameterChanges(cfg, map[string]interface{}{
+			"max_connections": "lots",
+			"autovacuum":      "maybe",
+			"no_such_param":   "1",
+			"work_mem":        "8MB",
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, want := range []string{
+			`parameters.max_connections: expected a number, got "lots"`,
+			`parameters.autovacuum: expected a bool`,
+			`parameters.no_such_param: not a parameter`,
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error should contain %q, got:\n%v", want, err)
+			}
+		}
+		if strings.Contains(err.Error(), "work_mem") {
+			t.Errorf("valid key reported: %v", err)
+		}
+	})
+}
+
+func TestParseConfigValue(t *testing.T) {
+	cases := []struct {
+		in      string
+		sample  interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{in: "42", sample: float64(1), want: float64(42)},
+		{in: " 0.5", sample: float64(1), want: 0.5},
+		{in: "on", sample: float64(1), wantErr: true},
+		{in: "true", sample: false, want: true},
+		{in: "OFF", sample: true, want: false},
+		{in: "2", sample: true, wantErr: true},
+		{in: `["a","b"]`, sample: []interface{}{}, want: []interface{}{"a", "b"}},
+		{in: "a, b", sample: []interface{}{}, want: []interface{}{"a", "b"}},
+		{in: "", sample: []interface{}{}, want: []interface{}{}},
+		{in: `["a"`, sample: []interface{}{}, wantErr: true},
+		{in: "128MB", sample: "64MB", want: "128MB"},
+	}
+	for _, tc := range cases {
+		got, err := parseConfigValue(tc.in, tc.sample)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseConfigValue(%q, %#v): wantErr=%v, got %v", tc.in, tc.sample, tc.wantErr, err)
+			continue
+		}
+		if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseConfigValue(%q, %#v) = %#v, want %#v", tc.in, tc.sample, got, tc.want)
+		}
+	}
+}
+
+func TestSuppressEquivalentConfigValue(t *testing.T) {
+	cases := []struct {
+		old, new string
+		want     bool
+	}{
+		{"100", "100.0", true},
+		{"100", "101", false},
+		{"true", "on", true},
+		{"false", "yes", false},
+		{`["a","b"]`, "a,b", true},
+		{`["a","b"]`, "b,a", false},
+		{"128MB", "128mb", false},
+		{"", "1", false},
+	}
+	for _, tc := range cases {
+		if got := suppressEquivalentConfigValue("parameters.x", tc.old, tc.new, nil); got != tc.want {
+			t.Errorf("suppress(%q, %q) = %v, want %v", tc.old, tc.new, got, tc.want)
+		}
+	}
+}
+
+func testAccCloDbaasClusterParametersConfig(maxConnections int) string {
+	return testAccCloDbaasClusterConfig(dbaasClusterName) + fmt.Sprintf(`
+
+resource "clo_dbaas_cluster_parameters" "%s" {
+	cluster_id        = clo_dbaas_cluster.%s.id
+	rollback_on_error = true
+	parameters = {
+		max_connections = "%d"
+	}
+}`, dbaasClusterParametersName, dbaasClusterName, maxConnections)
+}
+
+func testAccCheckDbaasClusterParameter(n, key, want string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		cfg, e := cli.GetClusterConfig(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		if got := stringifyConfigValue(cfg.Current[key]); got != want {
+			return fmt.Errorf("cluster %s: %s is %q, want %q", rs.Primary.ID, key, got, want)
+		}
+		return nil
+	}
+}
@@ -3,10 +3,14 @@ package clo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -41,6 +45,127 @@ func TestAccCloDbaasCluster_basic(t *testing.T) {
 	})
 }
 
+func TestAccCloDbaasCluster_backupSchedule(t *testing.T) {
+	skipIfNotPreview(t)
+	cl := new(cloapi.Cluster)
+	addr := fmt.Sprintf("clo_dbaas_cluster.%s", dbaasClusterName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDbaasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloDbaasClusterBackupSchedule(3, 14),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasClusterExists(addr, cl),
+					resource.TestCheckResourceAttr(addr, "backup_hour", "3"),
+					resource.TestCheckResourceAttr(addr, "backup_retention", "14"),
+				),
+			},
+			{
+				// Midnight is a valid hour, not an unset one.
+				Config: testAccCloDbaasClusterBackupSchedule(0, 7),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasClusterExists(addr, cl),
+					resource.TestCheckResourceAttr(addr, "backup_hour", "0"),
+					resource.TestCheckResourceAttr(addr, "backup_retention", "7"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloDbaasCluster_replicas(t *testing.T) {
+	skipIfNotPreview(t)
+	cl := new(cloapi.Cluster)
+	addr := fmt.Sprintf("clo_dbaas_cluster.%s", dbaasClusterName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDbaasClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloDbaasClusterReplicas(1, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasClusterExists(addr, cl),
+					resource.TestCheckResourceAttr(addr, "replicas", "1"),
+					resource.TestCheckResourceAttr(addr, "nodes_count", "2"),
+				),
+			},
+			{
+				// Promote the replica; the old primary becomes the replica.
+				Config: testAccCloDbaasClusterReplicas(1, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("clo_dbaas_switchover.promote", "primary_node_id", "clo_dbaas_switchover.promote", "node_id"),
+				),
+			},
+			{
+				Config: testAccCloDbaasClusterReplicas(0, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasClusterExists(addr, cl),
+					resource.TestCheckResourceAttr(addr, "replicas", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloDbaasClusterReplicas(replicas int, switchover bool) string {
+	cfg := fmt.Sprintf(`
+data "clo_dbaas_datastores" "all" {
+	project_id = "%s"
+}
+
+resource "clo_dbaas_cluster" "%s" {
+	project_id   = "%s"
+	name         = "%s"
+	datastore_id = data.clo_dbaas_datastores.all.result[0].id
+	storage_size = 10
+	replicas     = %d
+	flavor {
+		vcpus = 1
+		ram   = 2
+	}
+}`, projectID, dbaasClusterName, projectID, dbaasClusterName, replicas)
+	if switchover {
+		cfg += fmt.Sprintf(`
+
+data "clo_dbaas_nodes" "all" {
+	cluster_id = clo_dbaas_cluster.%[1]s.id
+}
+
+resource "clo_dbaas_switchover" "promote" {
+	cluster_id = clo_dbaas_cluster.%[1]s.id
+	node_id    = [for n in data.clo_dbaas_nodes.all.result : n.id if n.role == "REPLICA"][0]
+
+	lifecycle {
+		ignore_changes = [node_id]
+	}
+}`, dbaasClusterName)
+	}
+	return cfg
+}
+
+func testAccCloDbaasClusterBackupSchedule(hour, retention int) string {
+	return fmt.Sprintf(`
+data "clo_dbaas_datastores" "all" {
+	project_id = "%s"
+}
+
+resource "clo_dbaas_cluster" "%s" {
+	project_id       = "%s"
+	name             = "%s"
+	datastore_id     = data.clo_dbaas_datastores.all.result[0].id
+	storage_size     = 10
+	backup_hour      = %d
+	backup_retention = %d
+	flavor {
+		vcpus = 1
+		ram   = 2
+	}
+}`, projectID, dbaasClusterName, projectID, dbaasClusterName, hour, retention)
+}
+
 func testAccCloDbaasClusterConfig(name string) string {
 	return fmt.Sprintf(`
 data "clo_dbaas_datastores" "all" {
@@ -95,3 +220,274 @@ func testAccCheckDbaasClusterDestroy(st *terraform.State) error {
 	}
 	return nil
 }
+
+func TestPickPointInTimeBackup(t *testing.T) {
+	backups := []cloapi.Backup{
+		{ID: "full-1", ClusterID: "cl-1", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-10-01T03:00:00Z"},
+		{ID: "full-2", ClusterID: "cl-1", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-10-02T03:00:00Z"},
+		{ID: "partial", ClusterID: "cl-1", Type: "PARTIAL", Status: availableBackup, CreatedIn: "2026-10-02T09:00:00Z"},
+		{ID: "building", ClusterID: "cl-1", Type: fullBackup, Status: buildBackup, CreatedIn: "2026-10-03T03:00:00Z"},
+		{ID: "other", ClusterID: "cl-2", Type: fullBackup, Status: availableBackup, CreatedIn: "2026-09-01T03:00:00Z"},
+	}
+	now := time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	cases := []struct {
+		at      string
+		want    string
+		wantErr string
+	}{
+		{at: "2026-10-01T03:00:00Z", want: "full-1"},
+		{at: "2026-10-01T20:00:00Z", want: "full-1"},
+		{at: "2026-10-02T12:00:00+03:00", want: "full-2"},
+		{at: "2026-10-03T11:00:00Z", want: "full-2"},
+		{at: "2026-09-30T23:00:00Z", wantErr: "before the oldest FULL backup"},
+		{at: "2026-10-04T00:00:00Z", wantErr: "in the future"},
+	}
+	for _, c := range cases {
+		b, err := pickPointInTimeBackup(backups, "cl-1", at(c.at), now)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) || !strings.Contains(err.Error(), "2026-10-01T03:00:00Z to 2026-10-03T12:00:00Z") {
+				t.Errorf("%s: expected %q error naming the window, got %v", c.at, c.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.at, err)
+			continue
+		}
+		if b.ID != c.want {
+			t.Errorf("%s: picked %s, want %s", c.at, b.ID, c.want)
+		}
+	}
+
+	if _, err := pickPointInTimeBackup(backups, "cl-3", now, now); err == nil {
+		t.Error("expected an error for a cluster without FULL backups")
+	}
+}
+
+func TestClusterReplicas(t *testing.T) {
+	nodes := []cloapi.Node{
+		{ID: "r-new", Role: replicaNode, CreatedIn: "2026-10-03T00:00:00Z"},
+		{ID: "p", Role: primaryNode, CreatedIn: "2026-10-01T00:00:00Z"},
+		{ID: "r-old", Role: replicaNode, CreatedIn: "2026-10-02T00:00:00+03:00"},
+	}
+	got := clusterReplicas(nodes)
+	if len(got) != 2 || got[0].ID != "r-old" || got[1].ID != "r-new" {
+		t.Errorf("replicas = %v, want r-old then r-new", got)
+	}
+}
+
+func TestClusterNodesState(t *testing.T) {
+	active := []cloapi.Node{{ID: "p", Status: activeNode}, {ID: "r", Status: activeNode}}
+	cases := []struct {
+		name  string
+		nodes []cloapi.Node
+		count int
+		want  string
+	}{
+		{"settled", active, 2, activeNode},
+		{"replica not listed yet", active, 3, buildNode},
+		{"replica still listed", active, 1, deletingNode},
+		{"replica building", []cloapi.Node{{Status: activeNode}, {Status: buildNode}}, 2, buildNode},
+		{"failed node", []cloapi.Node{{Status: activeNode}, {Status: "ERROR"}}, 2, "ERROR"},
+	}
+	for _, c := range cases {
+		if got := clusterNodesState(c.nodes, c.count); got != c.want {
+			t.Errorf("%s: state = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDatastoreUpgradeInPlace(t *testing.T) {
+	pg := func(v string) cloapi.Datastore { return cloapi.Datastore{Name: "PostgreSQL", Version: v} }
+	my := func(v string) cloapi.Datastore { return cloapi.Datastore{Name: "MySQL", Version: v} }
+	cases := []struct {
+		from, to cloapi.Datastore
+		inPlace  bool
+	}{
+		{pg("14.5"), pg("14.9"), true},
+		{pg("14"), pg("14.1"), true},
+		{pg("14.9"), pg("15.1"), false},
+		{pg("14.9"), pg("14.5"), false},
+		{my("8.0.32"), my("8.0.35-1"), true},
+		{my("8.0.35"), my("8.4.0"), false},
+		{my("8.0.35"), pg("8.0.36"), false},
+		{pg("devel"), pg("14.1"), false},
+	}
+	for _, c := range cases {
+		err := datastoreUpgradeInPlace(c.from, c.to)
+		if (err == nil) != c.inPlace {
+			t.Errorf("%s %s -> %s %s: in place = %v, want %v (%v)", c.from.Name, c.from.Version, c.to.Name, c.to.Version, err == nil, c.inPlace, err)
+		}
+	}
+}
+
+// unknownValue is how a raw test config marks a value that is unknown until
+// apply (the SDK's hcl2shim.UnknownVariableValue, which is internal).
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestDbaasClusterUnknownDatastoreDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "cl-1",
+		Attributes: map[string]string{
+			"id":             "cl-1",
+			"project_id":     "p-1",
+			"name":           "db",
+			"storage_size":   "20",
+			"datastore_id":   "ds-1",
+			"flavor.#":       "1",
+			"flavor.0.vcpus": "2",
+			"flavor.0.ram":   "4",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":   "p-1",
+		"name":         "db",
+		"storage_size": 20,
+		"datastore_id": unknownValue,
+		"flavor":       []interface{}{map[string]interface{}{"vcpus": 2, "ram": 4}},
+	})
+	// The new datastore cannot be looked up, so the plan needs no API calls.
+	_, err := resourceDbaasCluster().Diff(context.Background(), state, config, nil)
+	if err == nil || !strings.Contains(err.Error(), "datastore_id") {
+		t.Errorf("an unknown datastore_id must be refused, got %v", err)
+	}
+}
+
+func TestDbaasClusterPointInTimeNeedsPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":   "p-1",
+		"name":         "db",
+		"storage_size": 20,
+		"datastore_id": "ds-1",
+		"flavor":       []interface{}{map[string]interface{}{"vcpus": 2, "ram": 4}},
+		"restore_point_in_time": []interface{}{map[string]interface{}{
+			"source_cluster_id": "cl-1",
+			"timestamp":         "2026-10-01T12:30:00Z",
+		}},
+	})
+	// Refused before the restore window is looked up, so no API calls.
+	_, err = resourceDbaasCluster().Diff(context.Background(), nil, config, &providerMeta{v3: cli})
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("restore_point_in_time without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
+
+func TestDbaasClusterReplicasNeedPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":   "p-1",
+		"name":         "db",
+		"storage_size": 20,
+		"datastore_id": "ds-1",
+		"flavor":       []interface{}{map[string]interface{}{"vcpus": 2, "ram": 4}},
+		"replicas":     1,
+	})
+	state := &terraform.InstanceState{RawConfig: rawConfig(resourceDbaasCluster(), map[string]cty.Value{"replicas": cty.NumberIntVal(1)})}
+	_, err = resourceDbaasCluster().Diff(context.Background(), state, config, &providerMeta{v3: cli})
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("replicas without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
+
+func TestDbaasClusterBackupScheduleDiff(t *testing.T) {
+	config := func(schedule map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"project_id":   "p-1",
+			"name":         "db",
+			"storage_size": 20,
+			"datastore_id": "ds-1",
+			"flavor":       []interface{}{map[string]interface{}{"vcpus": 2, "ram": 4}},
+		}
+		for k, v := range schedule {
+			raw[k] = v
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+	meta := func(preview bool) *providerMeta {
+		cli, err := cloapi.New("token", "https://api.example.com", preview)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &providerMeta{v3: cli}
+	}
+	cases := []struct {
+		name     string
+		preview  bool
+		schedule map[string]interface{}
+		wantErr  string
+	}{
+		{name: "unset_without_preview"},
+		{name: "hour_without_preview", schedule: map[string]interface{}{"backup_hour": 3, "backup_retention": 7}, wantErr: "preview_endpoints"},
+		{name: "hour_without_retention", preview: true, schedule: map[string]interface{}{"backup_hour": 3}, wantErr: "backup_retention must be set"},
+		{name: "hour_and_retention", preview: true, schedule: map[string]interface{}{"backup_hour": 3, "backup_retention": 7}},
+		{name: "retention_only", preview: true, schedule: map[string]interface{}{"backup_retention": 7}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]cty.Value{}
+			for k, v := range tc.schedule {
+				raw[k] = cty.NumberIntVal(int64(v.(int)))
+			}
+			// The check reads the raw config, which only the protocol sets.
+			state := &terraform.InstanceState{RawConfig: rawConfig(resourceDbaasCluster(), raw)}
+			_, err := resourceDbaasCluster().Diff(context.Background(), state, config(tc.schedule), meta(tc.preview))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// rawConfig builds the raw config Terraform would send for r with the given
+// top-level attributes set and every other one null.
+func rawConfig(r *schema.Resource, attrs map[string]cty.Value) cty.Value {
+	ty := r.CoreConfigSchema().ImpliedType()
+	vals := make(map[string]cty.Value, len(ty.AttributeTypes()))
+	for name, at := range ty.AttributeTypes() {
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = cty.NullVal(at)
+		}
+	}
+	return cty.ObjectVal(vals)
+}
+
+func TestVersionParts(t *testing.T) {
+	cases := map[string][]int{
+		"14":          {14},
+		"8.0.35":      {8, 0, 35},
+		"8.0.35-1":    {8, 0, 35},
+		"7.2rc1.4":    {7, 2},
+		"15.x":        {15},
+		"":            nil,
+		"postgres-16": nil,
+	}
+	for v, want := range cases {
+		got := versionParts(v)
+		if compareVersions(got, want) != 0 || len(got) != len(want) {
+			t.Errorf("versionParts(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
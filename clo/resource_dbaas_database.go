@@ -2,11 +2,16 @@ package clo
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Dbaas database lifecycle statuses, per the cloud_dbaas_database model. ERROR is
@@ -15,13 +20,18 @@ import (
 const (
 	buildDatabase    = "BUILD"
 	readyDatabase    = "READY"
+	updatingDatabase = "UPDATING"
 	deletingDatabase = "DELETING"
 	deletedDatabase  = "DELETED"
 )
 
 func resourceDbaasDatabase() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Manage a database inside a managed-database (dbaas) cluster. Each database carries its own admin user; rotate the admin password by changing `admin_password`.",
+		Description: "Manage a database inside a managed-database (dbaas) cluster. Each database carries its own admin user; " +
+			"rotate the admin password by changing `admin_password` or bumping `admin_password_version`. Changing `name` " +
+			"renames the database in place on PostgreSQL, which needs the provider's `preview_endpoints`, and replaces it on engines " +
+			"without a rename statement. `charset` and `collation` also need `preview_endpoints`. They " +
+			"are not reported by the API, so on an imported database a configured value cannot be compared and does not replace it.",
 		ReadContext:   resourceDbaasDatabaseRead,
 		CreateContext: resourceDbaasDatabaseCreate,
 		UpdateContext: resourceDbaasDatabaseUpdate,
@@ -32,6 +42,7 @@ func resourceDbaasDatabase() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
+		CustomizeDiff: customdiff.All(planDbaasDatabaseRename, validateDbaasDatabaseCharset),
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Description: "ID of the dbaas cluster the database belongs to",
@@ -40,10 +51,25 @@ func resourceDbaasDatabase() *schema.Resource {
 				ForceNew:    true,
 			},
 			"name": {
-				Description: "Name of the database",
+				Description: "Name of the database. Renamed in place on PostgreSQL; on other engines a change replaces the database and its data.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+			},
+			"charset": {
+				Description:      "Character set (encoding) of the database, e.g. `UTF8` on PostgreSQL or `utf8mb4` on MySQL. Only set at creation; not supported on Redis. Defaults to the engine default.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressUnknownCharset,
+				ValidateFunc:     validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "must be a character set name"),
+			},
+			"collation": {
+				Description:      "Collation of the database, e.g. `en_US.UTF-8` on PostgreSQL or `utf8mb4_unicode_ci` on MySQL. Only set at creation; not supported on Redis. Defaults to the engine default.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressUnknownCharset,
+				ValidateFunc:     validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_.@-]+$`), "must be a collation name"),
 			},
 			"admin_username": {
 				Description: "Username of the database's admin user",
@@ -57,6 +83,11 @@ func resourceDbaasDatabase() *schema.Resource {
 				Required:    true,
 				Sensitive:   true,
 			},
+			"admin_password_version": {
+				Description: "Change this value to apply `admin_password` to the database again, e.g. after the password was reset outside Terraform or when `admin_password` comes from a generator keyed on this value.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
 			"id": {
 				Description: "ID of the database",
 				Type:        schema.TypeString,
@@ -93,6 +124,8 @@ func resourceDbaasDatabaseCreate(ctx context.Context, d *schema.ResourceData, m
 		Name:          d.Get("name").(string),
 		AdminUsername: d.Get("admin_username").(string),
 		AdminPassword: d.Get("admin_password").(string),
+		Charset:       d.Get("charset").(string),
+		Collation:     d.Get("collation").(string),
 	})
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,8 +156,8 @@ func resourceDbaasDatabaseRead(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	// admin_password is deliberately not read back: the API never returns it, so
-	// the configured value is preserved in state as-is.
+	// admin_password, charset and collation are deliberately not read back: the
+	// API never returns them, so the configured values are preserved in state as-is.
 	fields := map[string]interface{}{
 		"id":             db.ID,
 		"name":           db.Name,
@@ -145,7 +178,15 @@ func resourceDbaasDatabaseRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceDbaasDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
-	if d.HasChange("admin_password") {
+	if d.HasChange("name") {
+		if err := cli.RenameDatabase(ctx, d.Id(), d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitDatabaseState(ctx, d.Id(), cli, []string{updatingDatabase}, []string{readyDatabase}, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChanges("admin_password", "admin_password_version") {
 		if err := cli.RestoreAdminPassword(ctx, d.Id(), d.Get("admin_password").(string)); err != nil {
 			return diag.FromErr(err)
 		}
@@ -175,6 +216,82 @@ func resourceDbaasDatabaseDelete(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
+// planDbaasDatabaseRename replaces the database on a name change unless the
+// cluster's engine can rename it in place. An in-place rename needs a preview
+// endpoint; without it the change is refused rather than turned into a
+// replacement that would drop the data.
+func planDbaasDatabaseRename(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("name") || d.HasChange("cluster_id") {
+		return nil
+	}
+	cluster, err := m.(*providerMeta).v3.GetCluster(ctx, d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+	if !databaseRenameInPlace(cluster.DatastoreName) {
+		return d.ForceNew("name")
+	}
+	return requirePreview(m, "name: renaming in place")
+}
+
+// suppressUnknownCharset hides a charset or collation set in the config of an
+// existing database whose state has none, e.g. after an import. The API does
+// not report either, so the value cannot be compared and must not replace the
+// database.
+func suppressUnknownCharset(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
+}
+
+// validateDbaasDatabaseCharset checks charset and collation against the
+// cluster's engine when a database is planned for creation. On an existing
+// database without either in state, suppressUnknownCharset hides the change.
+// Only the preview create can send either, so both are refused while preview
+// endpoints are disabled.
+func validateDbaasDatabaseCharset(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		oc, _ := d.GetChange("charset")
+		ol, _ := d.GetChange("collation")
+		if !d.HasChanges("charset", "collation") || oc.(string) == "" && ol.(string) == "" {
+			return nil
+		}
+	}
+	charset, collation := d.Get("charset").(string), d.Get("collation").(string)
+	if charset == "" && collation == "" {
+		return nil
+	}
+	if err := requirePreview(m, "charset and collation"); err != nil {
+		return err
+	}
+	if !d.NewValueKnown("cluster_id") {
+		return nil
+	}
+	cluster, err := m.(*providerMeta).v3.GetCluster(ctx, d.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+	return databaseCharsetSupported(cluster.DatastoreName, charset, collation)
+}
+
+// databaseRenameInPlace reports whether the engine can rename a database.
+// MySQL has no RENAME DATABASE and Redis databases are numbered.
+func databaseRenameInPlace(engine string) bool {
+	return strings.Contains(strings.ToLower(engine), "postgres")
+}
+
+// databaseCharsetSupported reports why charset and collation cannot be used on
+// the engine. MySQL collations are named after their character set.
+func databaseCharsetSupported(engine, charset, collation string) error {
+	e := strings.ToLower(engine)
+	switch {
+	case strings.Contains(e, "redis"):
+		return fmt.Errorf("%s databases have no charset or collation", engine)
+	case strings.Contains(e, "mysql") && charset != "" && collation != "" &&
+		!strings.HasPrefix(strings.ToLower(collation), strings.ToLower(charset)+"_"):
+		return fmt.Errorf("collation %q does not belong to charset %q", collation, charset)
+	}
+	return nil
+}
+
 // Waiters
 
 func waitDatabaseState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
@@ -3,6 +3,7 @@ package clo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
@@ -47,6 +48,14 @@ func TestAccCloDbaasDatabase_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(addr, "admin_password", "S3cret-pass-2"),
 				),
 			},
+			{
+				// Re-apply the same password without changing it.
+				Config: testAccCloDbaasDatabaseVersionConfig(clusterID, "S3cret-pass-2", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasDatabaseExists(addr, db),
+					resource.TestCheckResourceAttr(addr, "admin_password_version", "2"),
+				),
+			},
 		},
 	})
 }
@@ -60,6 +69,134 @@ func testAccCloDbaasDatabaseConfig(clusterID, password string) string {
 }`, dbaasDatabaseName, clusterID, password)
 }
 
+func testAccCloDbaasDatabaseVersionConfig(clusterID, password string, version int) string {
+	return fmt.Sprintf(`resource "clo_dbaas_database" "%s" {
+	cluster_id             = "%s"
+	name                   = "appdb"
+	admin_username         = "app_admin"
+	admin_password         = "%s"
+	admin_password_version = %d
+}`, dbaasDatabaseName, clusterID, password, version)
+}
+
+func TestDatabaseRenameInPlace(t *testing.T) {
+	cases := map[string]bool{
+		"PostgreSQL": true,
+		"postgres":   true,
+		"MySQL":      false,
+		"Redis":      false,
+	}
+	for engine, want := range cases {
+		if got := databaseRenameInPlace(engine); got != want {
+			t.Errorf("databaseRenameInPlace(%q) = %v, want %v", engine, got, want)
+		}
+	}
+}
+
+func TestDatabaseCharsetSupported(t *testing.T) {
+	cases := []struct {
+		engine, charset, collation string
+		ok                         bool
+	}{
+		{"PostgreSQL", "UTF8", "en_US.UTF-8", true},
+		{"MySQL", "utf8mb4", "utf8mb4_unicode_ci", true},
+		{"MySQL", "utf8mb4", "", true},
+		{"MySQL", "", "latin1_swedish_ci", true},
+		{"MySQL", "utf8mb4", "latin1_swedish_ci", false},
+		{"Redis", "UTF8", "", false},
+	}
+	for _, c := range cases {
+		err := databaseCharsetSupported(c.engine, c.charset, c.collation)
+		if (err == nil) != c.ok {
+			t.Errorf("databaseCharsetSupported(%q, %q, %q) = %v, want ok=%v", c.engine, c.charset, c.collation, err, c.ok)
+		}
+	}
+}
+
+func TestDbaasDatabasePasswordVersionDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "db-1",
+		Attributes: map[string]string{
+			"id":                     "db-1",
+			"cluster_id":             "cl-1",
+			"name":                   "appdb",
+			"admin_username":         "app_admin",
+			"admin_password":         "S3cret-pass-1",
+			"admin_password_version": "1",
+			"backup_enabled":         "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":             "cl-1",
+		"name":                   "appdb",
+		"admin_username":         "app_admin",
+		"admin_password":         "S3cret-pass-1",
+		"admin_password_version": 2,
+	})
+	// Neither the name nor the charset changes, so the plan needs no API calls.
+	diff, err := resourceDbaasDatabase().Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil || diff.Attributes["admin_password_version"] == nil {
+		t.Fatalf("expected a change to admin_password_version, got %v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Error("bumping admin_password_version must not replace the database")
+	}
+}
+
+func TestDbaasDatabaseImportedCharsetDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "db-1",
+		Attributes: map[string]string{
+			"id":             "db-1",
+			"cluster_id":     "cl-1",
+			"name":           "appdb",
+			"admin_username": "app_admin",
+			"admin_password": "S3cret-pass-1",
+			"backup_enabled": "false",
+		},
+	}
+	raw := map[string]interface{}{
+		"cluster_id":     "cl-1",
+		"name":           "appdb",
+		"admin_username": "app_admin",
+		"admin_password": "S3cret-pass-1",
+		"charset":        "UTF8",
+		"collation":      "en_US.UTF-8",
+	}
+	// An imported database has no charset in state; the configured one cannot
+	// be compared and must not replace it.
+	diff, err := resourceDbaasDatabase().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["charset"] != nil || diff.Attributes["collation"] != nil) {
+		t.Errorf("charset/collation on an imported database should not plan a change: %v", diff)
+	}
+
+}
+
+func TestDbaasDatabaseCharsetNeedsPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":     "cl-1",
+		"name":           "appdb",
+		"admin_username": "app_admin",
+		"admin_password": "S3cret-pass-1",
+		"charset":        "UTF8",
+	})
+	// Refused before the cluster's engine is looked up, so no API calls.
+	_, err = resourceDbaasDatabase().Diff(context.Background(), nil, config, &providerMeta{v3: cli})
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("charset without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
+
 func testAccCheckDbaasDatabaseExists(n string, item *cloapi.Database) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[n]
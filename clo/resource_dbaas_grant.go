@@ -0,0 +1,161 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dbaasPrivileges are the privilege names the API accepts, the union of what the
+// supported engines understand. The API rejects a privilege the cluster's engine
+// does not have.
+var dbaasPrivileges = []string{
+	"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES",
+	"TRIGGER", "CREATE", "DROP", "ALTER", "INDEX", "EXECUTE", "TEMPORARY",
+	"CONNECT", "CREATE VIEW", "SHOW VIEW", "CREATE ROUTINE", "ALTER ROUTINE",
+	"LOCK TABLES", "EVENT",
+}
+
+func resourceDbaasGrant() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Grant a `clo_dbaas_user` a set of privileges on one database of its cluster. The privileges are read back from the API, so grants changed outside Terraform show up as drift.",
+		ReadContext:   resourceDbaasGrantRead,
+		CreateContext: resourceDbaasGrantCreate,
+		UpdateContext: resourceDbaasGrantUpdate,
+		DeleteContext: resourceDbaasGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDbaasGrant,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "ID of the dbaas user receiving the privileges",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"database_id": {
+				Description: "ID of the dbaas database the privileges apply to. It must belong to the user's cluster.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"privileges": {
+				Description: "Privileges to grant, e.g. `SELECT`, `INSERT` or `ALL`. Changing the set regrants in place.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(dbaasPrivileges, false),
+				},
+			},
+		},
+	}
+}
+
+func resourceDbaasGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	userID, databaseID := d.Get("user_id").(string), d.Get("database_id").(string)
+	if err := cli.SetDbaasGrant(ctx, userID, databaseID, buildDbaasGrantPrivileges(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(userID + "/" + databaseID)
+	return resourceDbaasGrantRead(ctx, d, m)
+}
+
+func resourceDbaasGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	userID, databaseID := d.Get("user_id").(string), d.Get("database_id").(string)
+	grants, err := cli.ListDbaasUserGrants(ctx, userID)
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	privileges := dbaasGrantPrivileges(grants, databaseID)
+	// A grant revoked outside Terraform is gone, not empty: recreate it.
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+	if err := d.Set("privileges", privileges); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDbaasGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChange("privileges") {
+		if err := cli.SetDbaasGrant(ctx, d.Get("user_id").(string), d.Get("database_id").(string), buildDbaasGrantPrivileges(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceDbaasGrantRead(ctx, d, m)
+}
+
+func resourceDbaasGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.RevokeDbaasGrant(ctx, d.Get("user_id").(string), d.Get("database_id").(string)); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func importDbaasGrant(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	userID, databaseID, ok := strings.Cut(d.Id(), "/")
+	if !ok || userID == "" || databaseID == "" || strings.Contains(databaseID, "/") {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <user_id>/<database_id>", d.Id())
+	}
+	fields := map[string]interface{}{
+		"user_id":     userID,
+		"database_id": databaseID,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return nil, e
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildDbaasGrantPrivileges(d *schema.ResourceData) []string {
+	ps := d.Get("privileges").(*schema.Set).List()
+	privileges := make([]string, len(ps))
+	for i, v := range ps {
+		privileges[i] = v.(string)
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+// dbaasGrantPrivileges returns the privileges held on databaseID, upper-cased the
+// way they are configured.
+func dbaasGrantPrivileges(grants []cloapi.DbaasGrant, databaseID string) []string {
+	for _, g := range grants {
+		if g.DatabaseID != databaseID {
+			continue
+		}
+		out := make([]string, 0, len(g.Privileges))
+		for _, p := range g.Privileges {
+			out = append(out, strings.ToUpper(p))
+		}
+		return out
+	}
+	return nil
+}
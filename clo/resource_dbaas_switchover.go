@@ -0,0 +1,139 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDbaasSwitchover() *schema.Resource {
+	return &schema.Resource{
+		Description: "Promote a replica node of a dbaas cluster to primary. This is an action: creating the " +
+			"resource performs the switchover and waits for every node to return to ACTIVE. Changing " +
+			"`node_id` or `triggers` performs it again. A later failover is not treated as drift, and " +
+			"destroying the resource leaves the node roles as they are.",
+		ReadContext:   resourceDbaasSwitchoverRead,
+		CreateContext: resourceDbaasSwitchoverCreate,
+		DeleteContext: resourceDbaasSwitchoverDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the dbaas cluster",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"node_id": {
+				Description: "ID of the node to promote. Resolve it with the `clo_dbaas_nodes` data source.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that, when changed, perform the switchover again",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"primary_node_id": {
+				Description: "ID of the cluster's current primary node",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDbaasSwitchoverCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	clusterID, nodeID := d.Get("cluster_id").(string), d.Get("node_id").(string)
+
+	nodes, err := cli.ListNodes(ctx, clusterID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	node := findClusterNode(nodes, nodeID)
+	if node == nil {
+		return diag.FromErr(fmt.Errorf("node %s is not a member of cluster %s", nodeID, clusterID))
+	}
+	// Promoting the node that already is the primary is a no-op; the API
+	// rejects it, so skip the call.
+	if node.Role != primaryNode {
+		if err := cli.SwitchoverCluster(ctx, clusterID, nodeID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitClusterPrimary(ctx, clusterID, nodeID, cli, len(nodes), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitClusterSettled(ctx, clusterID, cli, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(clusterID + "/" + nodeID)
+	return resourceDbaasSwitchoverRead(ctx, d, m)
+}
+
+func resourceDbaasSwitchoverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	nodes, err := cli.ListNodes(ctx, d.Get("cluster_id").(string))
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// node_id is the requested action, not the live role assignment, so it is
+	// kept as configured; primary_node_id reports where the primary is now.
+	primary := ""
+	for _, n := range nodes {
+		if n.Role == primaryNode {
+			primary = n.ID
+		}
+	}
+	if e := d.Set("primary_node_id", primary); e != nil {
+		return diag.FromErr(e)
+	}
+	return nil
+}
+
+// resourceDbaasSwitchoverDelete only forgets the action; it deliberately leaves
+// the node roles as they are.
+func resourceDbaasSwitchoverDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// waitClusterPrimary waits until nodeID is the primary and all count nodes are
+// ACTIVE. Until the roles have swapped the cluster counts as UPDATING, so a
+// refresh racing the start of the switchover does not end the wait early.
+func waitClusterPrimary(ctx context.Context, clusterID, nodeID string, cli *cloapi.Client, count int, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{buildNode, updatingNode, deletingNode}, []string{activeNode}, func() (interface{}, string, error) {
+		nodes, err := cli.ListNodes(ctx, clusterID)
+		if err != nil {
+			return nil, "", err
+		}
+		state := clusterNodesState(nodes, count)
+		if n := findClusterNode(nodes, nodeID); state == activeNode && (n == nil || n.Role != primaryNode) {
+			state = updatingNode
+		}
+		return nodes, state, nil
+	})
+}
+
+func findClusterNode(nodes []cloapi.Node, id string) *cloapi.Node {
+	for i := range nodes {
+		if nodes[i].ID == id {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
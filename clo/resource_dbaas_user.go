@@ -0,0 +1,187 @@
+package clo
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Dbaas user lifecycle statuses, mirroring the database ones. ERROR is
+// intentionally left out of every waiter's pending set, so StateChangeConf
+// surfaces it as an error instead of hanging.
+const (
+	buildUser    = "BUILD"
+	readyUser    = "READY"
+	deletingUser = "DELETING"
+	deletedUser  = "DELETED"
+
+	anyUserHost = "%"
+)
+
+func resourceDbaasUser() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage an additional login on a managed-database (dbaas) cluster. Unlike the admin user that `clo_dbaas_database` creates, a user starts with no privileges; grant them per database with `clo_dbaas_grant`.",
+		ReadContext:   resourceDbaasUserRead,
+		CreateContext: resourceDbaasUserCreate,
+		UpdateContext: resourceDbaasUserUpdate,
+		DeleteContext: resourceDbaasUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the dbaas cluster the user belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description:  "Username of the login. Letters, digits and underscores, starting with a letter or underscore; at most 32 characters.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,31}$`), "must be 1-32 letters, digits or underscores, starting with a letter or underscore"),
+			},
+			"password": {
+				Description:  "Password of the user. Changing it sets the new password on the running cluster. Write-only: the API never returns it, so its value is tracked from configuration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(8, 128),
+			},
+			"host": {
+				Description:  "Host mask the user may connect from, e.g. `10.0.0.%`. Defaults to `%` (any host). Only MySQL-family engines enforce it.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      anyUserHost,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"id": {
+				Description: "ID of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_in": {
+				Description: "Timestamp the user was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDbaasUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id, err := cli.CreateDbaasUser(ctx, d.Get("cluster_id").(string), cloapi.DbaasUserCreateParams{
+		Name:     d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Host:     d.Get("host").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitDbaasUserState(ctx, id, cli, []string{buildUser}, []string{readyUser}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceDbaasUserRead(ctx, d, m)
+}
+
+func resourceDbaasUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	u, err := cli.GetDbaasUser(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	host := u.Host
+	if host == "" {
+		host = anyUserHost
+	}
+	// password is deliberately not read back: the API never returns it, so the
+	// configured value is preserved in state as-is.
+	fields := map[string]interface{}{
+		"id":         u.ID,
+		"cluster_id": u.ClusterID,
+		"name":       u.Name,
+		"host":       host,
+		"status":     u.Status,
+		"created_in": u.CreatedIn,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceDbaasUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChange("password") {
+		if err := cli.ChangeDbaasUserPassword(ctx, d.Id(), d.Get("password").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitDbaasUserState(ctx, d.Id(), cli, []string{buildUser}, []string{readyUser}, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceDbaasUserRead(ctx, d, m)
+}
+
+func resourceDbaasUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeleteDbaasUser(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if err := waitDbaasUserDeleted(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Waiters
+
+func waitDbaasUserState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
+	return waitForState(ctx, timeout, pending, target, func() (interface{}, string, error) {
+		u, err := cli.GetDbaasUser(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return u, u.Status, nil
+	})
+}
+
+func waitDbaasUserDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{readyUser, deletingUser}, []string{deletedUser}, func() (interface{}, string, error) {
+		u, err := cli.GetDbaasUser(ctx, id)
+		if cloapi.IsNotFound(err) {
+			return struct{}{}, deletedUser, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return u, u.Status, nil
+	})
+}
@@ -0,0 +1,131 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloDbaasUser_grant(t *testing.T) {
+	skipIfNotPreview(t)
+	skipIfNotAcc(t)
+	cli, err := getTestClient()
+	if err != nil {
+		t.Fatal("Error get test client ", err)
+	}
+	clusterID, err := buildTestDbaasCluster(cli, t)
+	if err != nil {
+		t.Fatal("Error while create dbaas cluster ", err)
+	}
+
+	user := new(cloapi.DbaasUser)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDbaasUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloDbaasUserConfig(clusterID, "S3cret-pass-1", `"SELECT"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasUserExists("clo_dbaas_user.reader", user),
+					resource.TestCheckResourceAttr("clo_dbaas_user.reader", "name", "app_reader"),
+					resource.TestCheckResourceAttr("clo_dbaas_user.reader", "host", "%"),
+					resource.TestCheckResourceAttr("clo_dbaas_grant.reader", "privileges.#", "1"),
+				),
+			},
+			{
+				// Rotate the password and widen the grant in place.
+				Config: testAccCloDbaasUserConfig(clusterID, "S3cret-pass-2", `"SELECT", "INSERT"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbaasUserExists("clo_dbaas_user.reader", user),
+					resource.TestCheckResourceAttr("clo_dbaas_user.reader", "password", "S3cret-pass-2"),
+					resource.TestCheckResourceAttr("clo_dbaas_grant.reader", "privileges.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "clo_dbaas_grant.reader",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCloDbaasUserConfig(clusterID, password, privileges string) string {
+	return fmt.Sprintf(`resource "clo_dbaas_database" "app" {
+	cluster_id     = "%[1]s"
+	name           = "appdb"
+	admin_username = "app_admin"
+	admin_password = "S3cret-admin-1"
+}
+
+resource "clo_dbaas_user" "reader" {
+	cluster_id = "%[1]s"
+	name       = "app_reader"
+	password   = "%[2]s"
+}
+
+resource "clo_dbaas_grant" "reader" {
+	user_id     = clo_dbaas_user.reader.id
+	database_id = clo_dbaas_database.app.id
+	privileges  = [%[3]s]
+}`, clusterID, password, privileges)
+}
+
+func testAccCheckDbaasUserExists(n string, item *cloapi.DbaasUser) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("dbaas user ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		u, e := cli.GetDbaasUser(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *u
+		return nil
+	}
+}
+
+func testAccCheckDbaasUserDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_dbaas_user" {
+			continue
+		}
+		_, e := cli.GetDbaasUser(context.Background(), rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("dbaas user %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func TestDbaasGrantPrivileges(t *testing.T) {
+	grants := []cloapi.DbaasGrant{
+		{DatabaseID: "db-1", Privileges: []string{"select", "Insert"}},
+		{DatabaseID: "db-2", Privileges: []string{}},
+	}
+	if got := dbaasGrantPrivileges(grants, "db-1"); !reflect.DeepEqual(got, []string{"SELECT", "INSERT"}) {
+		t.Errorf("db-1 privileges = %v", got)
+	}
+	if got := dbaasGrantPrivileges(grants, "db-2"); len(got) != 0 {
+		t.Errorf("db-2 privileges = %v, want none", got)
+	}
+	if got := dbaasGrantPrivileges(grants, "db-3"); got != nil {
+		t.Errorf("db-3 privileges = %v, want nil", got)
+	}
+}
@@ -2,12 +2,15 @@ package clo
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -21,6 +24,11 @@ const (
 	deletedInstance  = "DELETED"
 
 	switchOnServer = "ON"
+
+	creatingInterface = "CREATING"
+	activeInterface   = "ACTIVE"
+	deletingInterface = "DELETING"
+	deletedInterface  = "DELETED"
 )
 
 func resourceInstance() *schema.Resource {
@@ -30,6 +38,12 @@ func resourceInstance() *schema.Resource {
 		CreateContext: resourceInstanceCreate,
 		UpdateContext: resourceInstanceUpdate,
 		DeleteContext: resourceInstanceDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.Get("network_interface").(*schema.Set).Len() == 0 {
+				return nil
+			}
+			return requirePreview(m, "network_interface")
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -132,6 +146,50 @@ func resourceInstance() *schema.Resource {
 					},
 				},
 			},
+			"network_interface": {
+				Description: "NICs plugged into private networks. Interfaces are attached after the instance is built. " +
+					"A NIC is identified by `network_id`, `subnet_id` and `fixed_ip`, so adding or removing a block " +
+					"attaches or detaches just that interface, and changing one of those fields replaces it. NICs attached " +
+					"outside Terraform are read with all three fields as the API reports them. Requires the provider's `preview_endpoints`.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      instanceInterfaceHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Description: "ID of the private network to plug the NIC into",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"subnet_id": {
+							Description: "ID of the subnet to take the address from. Defaults to the network's first subnet.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"fixed_ip": {
+							Description:  "Address to assign to the NIC. Allocated by DHCP when omitted.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"id": {
+							Description: "ID of the interface",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"address": {
+							Description: "Address the NIC got, whether fixed or allocated",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"mac_address": {
+							Description: "MAC address of the interface",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"keypairs": {
 				Description: "The list contains the SSH-keypairs IDs",
 				Type:        schema.TypeList,
@@ -200,6 +258,11 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 			return diag.FromErr(err)
 		}
 	}
+
+	noInterfaces := schema.NewSet(instanceInterfaceHash, nil)
+	if err := syncInstanceInterfaces(ctx, d, cli, noInterfaces, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceInstanceRead(ctx, d, m)
 }
 
@@ -228,6 +291,13 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
+	if d.HasChange("network_interface") {
+		o, _ := d.GetChange("network_interface")
+		if err := syncInstanceInterfaces(ctx, d, cli, o.(*schema.Set), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceInstanceRead(ctx, d, m)
 }
 
@@ -254,6 +324,20 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 			return diag.FromErr(e)
 		}
 	}
+
+	// No NIC can have been attached through the provider while preview
+	// endpoints are disabled, so there is nothing to refresh then.
+	ifaces, err := cli.ListServerInterfaces(ctx, d.Id())
+	switch {
+	case errors.Is(err, cloapi.ErrPreviewDisabled):
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+	state := d.Get("network_interface").(*schema.Set).List()
+	if e := d.Set("network_interface", flattenInstanceInterfaces(state, ifaces)); e != nil {
+		return diag.FromErr(e)
+	}
 	return nil
 }
 
@@ -392,6 +476,104 @@ func buildInstanceAddresses(d *schema.ResourceData) []cloapi.ServerAddress {
 	return out
 }
 
+// syncInstanceInterfaces detaches the NICs in old that are no longer
+// configured and attaches the configured ones that are new, waiting for each
+// change to settle. Untouched interfaces keep their ID. The state is updated
+// after every attach and detach, so a failure part way leaves it matching the
+// NICs the instance really has.
+func syncInstanceInterfaces(ctx context.Context, d *schema.ResourceData, cli *cloapi.Client, old *schema.Set, timeout time.Duration) error {
+	servID := d.Id()
+	want := d.Get("network_interface").(*schema.Set)
+	current := schema.NewSet(instanceInterfaceHash, old.List())
+	if err := d.Set("network_interface", current); err != nil {
+		return err
+	}
+
+	for _, o := range old.Difference(want).List() {
+		if id := o.(map[string]interface{})["id"].(string); id != "" {
+			if err := cli.DetachServerInterface(ctx, servID, id); err != nil && !cloapi.IsNotFound(err) {
+				return err
+			}
+			if err := waitInstanceInterfaceDeleted(ctx, servID, id, cli, timeout); err != nil {
+				return err
+			}
+		}
+		current.Remove(o)
+		if err := d.Set("network_interface", current); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range want.Difference(old).List() {
+		wm := w.(map[string]interface{})
+		id, err := cli.AttachServerInterface(ctx, servID, cloapi.ServerInterfaceParams{
+			NetworkID: wm["network_id"].(string),
+			SubnetID:  wm["subnet_id"].(string),
+			Address:   wm["fixed_ip"].(string),
+		})
+		if err != nil {
+			return err
+		}
+		// Track the NIC before waiting, so it is not attached again if the
+		// wait fails.
+		attached := map[string]interface{}{
+			"network_id": wm["network_id"],
+			"subnet_id":  wm["subnet_id"],
+			"fixed_ip":   wm["fixed_ip"],
+			"id":         id,
+		}
+		current.Add(attached)
+		if err := d.Set("network_interface", current); err != nil {
+			return err
+		}
+		if err := waitInstanceInterfaceActive(ctx, servID, id, cli, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// instanceInterfaceHash identifies a network_interface block by the fields the
+// user configures only, so the computed ID and addresses never move a NIC to
+// another element.
+func instanceInterfaceHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	network, _ := m["network_id"].(string)
+	subnet, _ := m["subnet_id"].(string)
+	fixedIP, _ := m["fixed_ip"].(string)
+	return schema.HashString(network + "|" + subnet + "|" + fixedIP)
+}
+
+// flattenInstanceInterfaces refreshes the interfaces from the API. For a NIC
+// already in state the configured fields are kept as they are, so the element
+// hashes do not change; one that disappeared is dropped so the next plan
+// re-attaches it. A NIC state does not know about, e.g. after an import or one
+// attached outside Terraform, is adopted with the network, subnet and address
+// the API reports.
+func flattenInstanceInterfaces(state []interface{}, ifaces []cloapi.ServerInterface) []interface{} {
+	byID := make(map[string]map[string]interface{}, len(state))
+	for _, s := range state {
+		sm := s.(map[string]interface{})
+		byID[sm["id"].(string)] = sm
+	}
+	out := make([]interface{}, 0, len(ifaces))
+	for _, i := range ifaces {
+		network, subnet, fixedIP := interface{}(i.Network), interface{}(i.Subnet), interface{}(i.Address)
+		if sm, ok := byID[i.ID]; ok {
+			network, subnet, fixedIP = sm["network_id"], sm["subnet_id"], sm["fixed_ip"]
+		}
+		out = append(out, map[string]interface{}{
+			"id":          i.ID,
+			"network_id":  network,
+			"subnet_id":   subnet,
+			"fixed_ip":    fixedIP,
+			"address":     i.Address,
+			"mac_address": i.MacAddress,
+		})
+	}
+	return out
+}
+
 // Waiters
 func waitInstanceDeleted(ctx context.Context, serverId string, cli *cloapi.Client, timeout time.Duration) error {
 	return waitForState(ctx, timeout, []string{deletingInstance}, []string{deletedInstance}, func() (interface{}, string, error) {
@@ -426,6 +608,31 @@ func waitInstanceEnabled(ctx context.Context, id string, cli *cloapi.Client, ena
 	return waitInstanceState(ctx, id, cli, []string{activeInstance, stoppingInstance}, []string{stoppedInstance}, timeout)
 }
 
+func waitInstanceInterfaceActive(ctx context.Context, serverId, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{creatingInterface}, []string{activeInterface}, instanceInterfaceRefresh(ctx, serverId, id, cli))
+}
+
+func waitInstanceInterfaceDeleted(ctx context.Context, serverId, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{activeInterface, deletingInterface}, []string{deletedInterface}, instanceInterfaceRefresh(ctx, serverId, id, cli))
+}
+
+// instanceInterfaceRefresh reports a NIC's status, or DELETED once it is gone
+// from the instance's interface list.
+func instanceInterfaceRefresh(ctx context.Context, serverId, id string, cli *cloapi.Client) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ifaces, err := cli.ListServerInterfaces(ctx, serverId)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, i := range ifaces {
+			if i.ID == id {
+				return i, i.Status, nil
+			}
+		}
+		return struct{}{}, deletedInterface, nil
+	}
+}
+
 func resizeServer(ctx context.Context, id string, vcpus int, ram int, cli *cloapi.Client, d *schema.ResourceData) error {
 	if err := cli.ResizeServer(ctx, id, ram, vcpus); err != nil {
 		return err
@@ -291,3 +291,61 @@ func atLeast(def, min int) int {
 	}
 	return def
 }
+
+func TestInstanceInterfaceHash(t *testing.T) {
+	configured := map[string]interface{}{"network_id": "net-1", "subnet_id": "", "fixed_ip": "10.0.1.10"}
+	inState := map[string]interface{}{
+		"network_id": "net-1", "subnet_id": "", "fixed_ip": "10.0.1.10",
+		"id": "if-1", "address": "10.0.1.10", "mac_address": "fa:16:3e:00:00:01",
+	}
+	if instanceInterfaceHash(configured) != instanceInterfaceHash(inState) {
+		t.Error("computed fields should not change the hash")
+	}
+	other := map[string]interface{}{"network_id": "net-1", "subnet_id": "", "fixed_ip": "10.0.1.11"}
+	if instanceInterfaceHash(configured) == instanceInterfaceHash(other) {
+		t.Error("a different fixed_ip should be a different NIC")
+	}
+}
+
+func TestFlattenInstanceInterfaces(t *testing.T) {
+	state := []interface{}{
+		map[string]interface{}{"network_id": "net-1", "subnet_id": "", "fixed_ip": "", "id": "if-1"},
+		map[string]interface{}{"network_id": "net-2", "subnet_id": "sub-2", "fixed_ip": "", "id": "if-2"},
+	}
+	got := flattenInstanceInterfaces(state, []cloapi.ServerInterface{
+		{ID: "if-1", Network: "net-1", Subnet: "sub-1", Address: "10.0.1.5", MacAddress: "fa:16:3e:00:00:01"},
+		{ID: "if-9", Network: "net-3", Subnet: "sub-3", Address: "10.0.3.7"},
+	})
+	if len(got) != 2 {
+		t.Fatalf("the detached NIC should be dropped and the unknown one adopted, got %v", got)
+	}
+	nic := got[0].(map[string]interface{})
+	if nic["subnet_id"] != "" || nic["address"] != "10.0.1.5" || nic["mac_address"] != "fa:16:3e:00:00:01" {
+		t.Errorf("configured fields should be kept and computed ones refreshed, got %v", nic)
+	}
+	if instanceInterfaceHash(nic) != instanceInterfaceHash(state[0]) {
+		t.Error("refreshing should not change the NIC's hash")
+	}
+	adopted := got[1].(map[string]interface{})
+	if adopted["network_id"] != "net-3" || adopted["subnet_id"] != "sub-3" || adopted["fixed_ip"] != "10.0.3.7" {
+		t.Errorf("an adopted NIC should take the fields the API reports, got %v", adopted)
+	}
+}
+
+func TestInstanceInterfacesNeedPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": "p-1",
+		"name":       "vm",
+		"network_interface": []interface{}{
+			map[string]interface{}{"network_id": "net-1"},
+		},
+	})
+	_, err = resourceInstance().Diff(context.Background(), nil, config, &providerMeta{v3: cli})
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("network_interface without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
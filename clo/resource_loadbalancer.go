@@ -2,11 +2,17 @@ package clo
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Load-balancer lifecycle statuses, per the cloud_loadbalancer schema. status
@@ -28,9 +34,22 @@ const (
 	switchOnLB = "ON"
 )
 
+// Healthmonitor types. Only HTTP monitors take http_method, url_path and
+// expected_codes; the API fills those with the defaults below when an HTTP
+// monitor omits them.
+const (
+	pingMonitor = "PING"
+	tcpMonitor  = "TCP"
+	httpMonitor = "HTTP"
+
+	defaultMonitorHttpMethod    = "GET"
+	defaultMonitorUrlPath       = "/"
+	defaultMonitorExpectedCodes = "200"
+)
+
 func resourceLoadBalancer() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Manage a load balancer in the project. `enabled` toggles the balancer's power state (start/stop).",
+		Description:   "Manage a load balancer in the project. `enabled` toggles the balancer's power state (start/stop). Listener rules may be declared inline with `rule` blocks or as separate `clo_network_loadbalancer_rule` resources, but not both for the same balancer.",
 		ReadContext:   resourceLoadBalancerRead,
 		CreateContext: resourceLoadBalancerCreate,
 		UpdateContext: resourceLoadBalancerUpdate,
@@ -41,6 +60,10 @@ func resourceLoadBalancer() *schema.Resource {
 			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
+		CustomizeDiff: customdiff.All(
+			validateLoadBalancerHealthmonitor,
+			validateLoadBalancerInlineRules,
+		),
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Description: "ID of the project where the load balancer should be created",
@@ -93,40 +116,116 @@ func resourceLoadBalancer() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
-							Description: "Health-check type. One of `PING`, `TCP`, `HTTP`",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "Health-check type. One of `PING`, `TCP`, `HTTP`",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{pingMonitor, tcpMonitor, httpMonitor}, false),
 						},
 						"delay": {
-							Description: "Seconds between health checks (interval; minimum 80)",
-							Type:        schema.TypeInt,
-							Required:    true,
+							Description:  "Seconds between health checks (interval; minimum 80). Must be greater than `timeout`.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(80),
 						},
 						"timeout": {
-							Description: "Seconds to wait for a health-check response (minimum 15)",
-							Type:        schema.TypeInt,
-							Required:    true,
+							Description:  "Seconds to wait for a health-check response (minimum 15). Must be less than `delay`.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(15),
 						},
 						"max_retries": {
-							Description: "Failed checks before a backend is marked down (1-10)",
-							Type:        schema.TypeInt,
-							Required:    true,
+							Description:  "Failed checks before a backend is marked down (1-10)",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 10),
 						},
 						"http_method": {
-							Description: "HTTP method for the check (HTTP type only), e.g. `GET`",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:      "HTTP method for the check (HTTP type only), e.g. `GET`. Defaults to `GET` on HTTP monitors.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringInSlice([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"}, false),
+							DiffSuppressFunc: suppressMonitorDefault(defaultMonitorHttpMethod),
 						},
 						"url_path": {
-							Description: "URL path to check (HTTP type only), e.g. `/health`",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:      "URL path to check (HTTP type only), e.g. `/health`. Defaults to `/` on HTTP monitors.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringMatch(regexp.MustCompile(`^/`), "must start with /"),
+							DiffSuppressFunc: suppressMonitorDefault(defaultMonitorUrlPath),
 						},
 						"expected_codes": {
-							Description: "Expected HTTP status codes (HTTP type only), e.g. `200`",
+							Description:      "Expected HTTP status codes (HTTP type only): a code (`200`), a range (`200-299`) or a comma-separated list of either (`200,301`). Defaults to `200` on HTTP monitors.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validateExpectedCodes,
+							DiffSuppressFunc: suppressMonitorDefault(defaultMonitorExpectedCodes),
+						},
+					},
+				},
+			},
+			"rule": {
+				Description: "Listener rules managed together with the balancer. Rules added or removed outside Terraform show up as a diff. " +
+					"Listeners other than `TCP` need the provider's `preview_endpoints`.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_id": {
+							Description: "ID of the address the rule listens on",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"external_protocol_port": {
+							Description:  "Port exposed on the load balancer's address",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"internal_protocol_port": {
+							Description:  "Port the traffic is forwarded to on the backend",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"protocol": {
+							Description:  "Listener protocol. One of `TCP`, `HTTP`, `HTTPS`. Defaults to `TCP`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      tcpListener,
+							ValidateFunc: validation.StringInSlice([]string{tcpListener, httpListener, httpsListener}, false),
+						},
+						"certificate_id": {
+							Description: "ID of the certificate presented to clients. Required for, and only allowed on, `HTTPS` rules.",
 							Type:        schema.TypeString,
 							Optional:    true,
 						},
+						"x_forwarded_for": {
+							Description: "Add an `X-Forwarded-For` header with the client address. `HTTP` and `HTTPS` rules only.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"redirect_http_to_https": {
+							Description: "Answer every request with a redirect to the same URL over HTTPS instead of forwarding it. `HTTP` rules only.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"id": {
+							Description: "ID of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Lifecycle status of the rule",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"server": {
+							Description: "ID of the backend server the rule targets",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -188,6 +287,10 @@ func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if err := createLoadBalancerRules(ctx, id, cli, d.Get("rule").(*schema.Set).List(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// A freshly created balancer comes up running; only act if the user asked for it stopped.
 	if !d.Get("enabled").(bool) {
 		if err := cli.StopLoadBalancer(ctx, id); err != nil {
@@ -231,6 +334,18 @@ func resourceLoadBalancerRead(ctx context.Context, d *schema.ResourceData, m int
 			return diag.FromErr(e)
 		}
 	}
+
+	// Rules are only owned here when declared inline; otherwise they belong to
+	// clo_network_loadbalancer_rule resources and must not show up as drift.
+	if v, ok := d.GetOk("rule"); ok {
+		rules, err := flattenLoadBalancerInlineRules(ctx, cli, lb.Project, lb.ID, v.(*schema.Set).List())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if e := d.Set("rule", rules); e != nil {
+			return diag.FromErr(e)
+		}
+	}
 	return nil
 }
 
@@ -264,6 +379,26 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
+	if d.HasChange("rule") {
+		o, n := d.GetChange("rule")
+		oldRules, newRules := o.(*schema.Set), n.(*schema.Set)
+		for _, r := range oldRules.Difference(newRules).List() {
+			ruleID := r.(map[string]interface{})["id"].(string)
+			if ruleID == "" {
+				continue
+			}
+			if err := cli.DeleteRule(ctx, ruleID); err != nil && !cloapi.IsNotFound(err) {
+				return diag.FromErr(err)
+			}
+			if err := waitRuleDeleted(ctx, ruleID, cli, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err := createLoadBalancerRules(ctx, id, cli, newRules.Difference(oldRules).List(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("enabled") {
 		enabled := d.Get("enabled").(bool)
 		if enabled {
@@ -351,7 +486,176 @@ func expandHealthmonitor(list []interface{}) cloapi.HealthmonitorParams {
 	return hm
 }
 
+// validateLoadBalancerHealthmonitor rejects HTTP-only fields on PING/TCP
+// monitors and a timeout that is not shorter than the delay. Values still
+// unknown at plan time are left for the API to check.
+func validateLoadBalancerHealthmonitor(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("healthmonitor.0.type") {
+		return nil
+	}
+	hm := expandHealthmonitor(d.Get("healthmonitor").([]interface{}))
+	if hm.Type != httpMonitor {
+		for k, v := range map[string]string{
+			"http_method":    hm.HttpMethod,
+			"url_path":       hm.UrlPath,
+			"expected_codes": hm.ExpectedCodes,
+		} {
+			if v != "" {
+				return fmt.Errorf("healthmonitor.%s is only valid for HTTP monitors, not %s", k, hm.Type)
+			}
+		}
+	}
+	if d.NewValueKnown("healthmonitor.0.delay") && d.NewValueKnown("healthmonitor.0.timeout") && hm.Timeout >= hm.Delay {
+		return fmt.Errorf("healthmonitor.timeout (%d) must be less than healthmonitor.delay (%d)", hm.Timeout, hm.Delay)
+	}
+	return nil
+}
+
+// validateExpectedCodes accepts a status code, a range of codes or a
+// comma-separated list of either, e.g. `200`, `200-299`, `200,301`.
+func validateExpectedCodes(i interface{}, k string) (warns []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	code := func(s string) (int, bool) {
+		n, err := strconv.Atoi(s)
+		return n, err == nil && len(s) == 3 && n >= 100 && n <= 599
+	}
+	for _, part := range strings.Split(v, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		from, ok := code(lo)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %q is not an HTTP status code", k, lo))
+			continue
+		}
+		if !isRange {
+			continue
+		}
+		to, ok := code(hi)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %q is not an HTTP status code", k, hi))
+			continue
+		}
+		if from > to {
+			errs = append(errs, fmt.Errorf("%s: range %q is reversed", k, part))
+		}
+	}
+	return
+}
+
+// suppressMonitorDefault hides the default the API fills in for an omitted
+// HTTP monitor field, so leaving it out of the config does not produce a
+// perpetual diff.
+func suppressMonitorDefault(def string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return new == "" && old == def
+	}
+}
+
+// validateLoadBalancerInlineRules checks each inline rule's listener options
+// at plan time. A certificate_id that is still unknown leaves the whole set
+// unknown, so the HTTPS-needs-a-certificate check then waits for apply.
+func validateLoadBalancerInlineRules(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	known := d.NewValueKnown("rule")
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		rm := r.(map[string]interface{})
+		l := expandRuleListener(func(k string) interface{} { return rm[k] })
+		err := validateRuleListener(l.Protocol, l.CertificateID, known, l.XForwardedFor, l.RedirectHTTPS)
+		if err == nil {
+			err = requireRuleListenerPreview(m, l)
+		}
+		if err != nil {
+			return fmt.Errorf("rule on port %d: %w", rm["external_protocol_port"].(int), err)
+		}
+	}
+	return nil
+}
+
+// createLoadBalancerRules creates the given inline rules one at a time, waiting
+// for each to become ACTIVE before the next (the API serialises rule changes
+// per balancer).
+func createLoadBalancerRules(ctx context.Context, lbID string, cli *cloapi.Client, rules []interface{}, timeout time.Duration) error {
+	for _, r := range rules {
+		m := r.(map[string]interface{})
+		p := cloapi.RuleCreateParams{
+			AddressID:            m["address_id"].(string),
+			ExternalProtocolPort: m["external_protocol_port"].(int),
+			InternalProtocolPort: m["internal_protocol_port"].(int),
+			Listener:             expandRuleListener(func(k string) interface{} { return m[k] }),
+		}
+		l := p.Listener
+		if err := validateRuleListener(l.Protocol, l.CertificateID, true, l.XForwardedFor, l.RedirectHTTPS); err != nil {
+			return fmt.Errorf("rule on port %d: %w", p.ExternalProtocolPort, err)
+		}
+		ruleID, err := cli.CreateRule(ctx, lbID, p)
+		if err != nil {
+			return err
+		}
+		if err := waitRuleState(ctx, ruleID, cli, []string{creatingRule}, []string{activeRule}, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenLoadBalancerInlineRules builds the `rule` set from ListRules. The API
+// reports a rule's address, not the address ID it was created with: rules
+// already in state keep their recorded address_id, and rules added outside
+// Terraform have theirs resolved from the project's addresses (left empty if
+// the address cannot be found), so they surface as a diff either way.
+func flattenLoadBalancerInlineRules(ctx context.Context, cli *cloapi.Client, projectID, lbID string, state []interface{}) ([]interface{}, error) {
+	rules, err := cli.ListRules(ctx, lbID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]string, len(state))
+	for _, s := range state {
+		m := s.(map[string]interface{})
+		if id, _ := m["id"].(string); id != "" {
+			known[id] = m["address_id"].(string)
+		}
+	}
+
+	var byAddress map[string]string
+	out := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		addressID, ok := known[r.ID]
+		if !ok {
+			if byAddress == nil {
+				addrs, err := cli.ListAddresses(ctx, projectID)
+				if err != nil {
+					return nil, err
+				}
+				byAddress = make(map[string]string, len(addrs))
+				for _, a := range addrs {
+					byAddress[a.Address] = a.ID
+				}
+			}
+			addressID = byAddress[r.Address]
+		}
+		l, err := getRuleListener(ctx, cli, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		rule := flattenRuleListener(l)
+		rule["id"] = r.ID
+		rule["address_id"] = addressID
+		rule["external_protocol_port"] = r.ExternalProtocolPort
+		rule["internal_protocol_port"] = r.InternalProtocolPort
+		rule["status"] = r.Status
+		rule["server"] = r.Server
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
 func flattenHealthmonitor(hm cloapi.Healthmonitor) []interface{} {
+	// PING/TCP monitors may still report the HTTP fields of an earlier HTTP
+	// configuration; they do not apply and would show up as drift.
+	if hm.Type != httpMonitor {
+		hm.HttpMethod, hm.UrlPath, hm.ExpectedCodes = "", "", ""
+	}
 	return []interface{}{map[string]interface{}{
 		"type":           hm.Type,
 		"delay":          hm.Delay,
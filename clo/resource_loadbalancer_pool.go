@@ -0,0 +1,313 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Pool lifecycle statuses. As with rules, the failure statuses are absent from
+// every pending set so StateChangeConf surfaces them as errors.
+const (
+	creatingPool = "CREATING"
+	activePool   = "ACTIVE"
+	updatingPool = "UPDATING"
+	deletingPool = "DELETING"
+	deletedPool  = "DELETED"
+)
+
+// Pool member admin states. DRAINING keeps established connections but sends
+// no new ones; DISABLED takes the member out of rotation entirely.
+const (
+	enabledMember  = "ENABLED"
+	drainingMember = "DRAINING"
+	disabledMember = "DISABLED"
+)
+
+func resourceLoadBalancerPool() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage a backend pool on a load balancer: traffic arriving on `external_protocol_port` is " +
+			"spread across the `member` backends by weight. Members can be drained or disabled in place through " +
+			"`admin_state`, and report the healthmonitor's view of them in `operating_status`.",
+		ReadContext:   resourceLoadBalancerPoolRead,
+		CreateContext: resourceLoadBalancerPoolCreate,
+		UpdateContext: resourceLoadBalancerPoolUpdate,
+		DeleteContext: resourceLoadBalancerPoolDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"loadbalancer_id": {
+				Description: "ID of the load balancer the pool belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the pool",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"external_protocol_port": {
+				Description:  "Port exposed on the load balancer's address",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"member": {
+				Description: "Backends of the pool. A member is identified by `address_id` and `port`; `weight` and `admin_state` change in place.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Set:         poolMemberHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_id": {
+							Description: "ID of the backend server's internal (FIXED) address",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"port": {
+							Description:  "Port the traffic is forwarded to on the backend",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"weight": {
+							Description:  "Relative share of new connections the member receives (1-256). Defaults to 1.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 256),
+						},
+						"admin_state": {
+							Description:  "One of `ENABLED`, `DRAINING` (finish established connections, accept no new ones), `DISABLED`. Defaults to `ENABLED`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      enabledMember,
+							ValidateFunc: validation.StringInSlice([]string{enabledMember, drainingMember, disabledMember}, false),
+						},
+						"id": {
+							Description: "ID of the member",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"server": {
+							Description: "ID of the backend server",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Lifecycle status of the member",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"operating_status": {
+							Description: "Health of the member as last observed by the load balancer's healthmonitor (`ONLINE`/`OFFLINE`/`DEGRADED`/`NO_MONITOR`)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"id": {
+				Description: "ID of the pool",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the pool",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceLoadBalancerPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	p := cloapi.PoolCreateParams{
+		Name:                 d.Get("name").(string),
+		ExternalProtocolPort: d.Get("external_protocol_port").(int),
+	}
+	for _, mem := range d.Get("member").(*schema.Set).List() {
+		p.Members = append(p.Members, expandPoolMember(mem.(map[string]interface{})))
+	}
+	id, err := cli.CreatePool(ctx, d.Get("loadbalancer_id").(string), p)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitPoolSettled(ctx, id, cli, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceLoadBalancerPoolRead(ctx, d, m)
+}
+
+func resourceLoadBalancerPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	p, err := cli.GetPool(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members := make([]interface{}, 0, len(p.Members))
+	for _, mem := range p.Members {
+		members = append(members, map[string]interface{}{
+			"id":               mem.ID,
+			"address_id":       mem.AddressID,
+			"port":             mem.Port,
+			"weight":           mem.Weight,
+			"admin_state":      mem.AdminState,
+			"server":           mem.Server,
+			"status":           mem.Status,
+			"operating_status": mem.OperatingStatus,
+		})
+	}
+	fields := map[string]interface{}{
+		"id":                     p.ID,
+		"loadbalancer_id":        p.Loadbalancer,
+		"name":                   p.Name,
+		"external_protocol_port": p.ExternalProtocolPort,
+		"status":                 p.Status,
+		"member":                 schema.NewSet(poolMemberHash, members),
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceLoadBalancerPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id := d.Id()
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("name") {
+		if err := cli.RenamePool(ctx, id, d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("member") {
+		o, n := d.GetChange("member")
+		oldMembers, newMembers := o.(*schema.Set), n.(*schema.Set)
+
+		// Members keep their identity (and ID) across weight/admin_state
+		// changes: the set hashes on address and port only.
+		for _, mem := range oldMembers.Difference(newMembers).List() {
+			memberID := mem.(map[string]interface{})["id"].(string)
+			if err := cli.RemovePoolMember(ctx, id, memberID); err != nil && !cloapi.IsNotFound(err) {
+				return diag.FromErr(err)
+			}
+			if err := waitPoolSettled(ctx, id, cli, timeout); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		for _, mem := range newMembers.List() {
+			nm := mem.(map[string]interface{})
+			if !oldMembers.Contains(mem) {
+				if _, err := cli.AddPoolMember(ctx, id, expandPoolMember(nm)); err != nil {
+					return diag.FromErr(err)
+				}
+			} else {
+				om := findPoolMember(oldMembers, poolMemberHash(mem))
+				if om == nil || (om["weight"] == nm["weight"] && om["admin_state"] == nm["admin_state"]) {
+					continue
+				}
+				if err := cli.UpdatePoolMember(ctx, id, om["id"].(string), nm["weight"].(int), nm["admin_state"].(string)); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			if err := waitPoolSettled(ctx, id, cli, timeout); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return resourceLoadBalancerPoolRead(ctx, d, m)
+}
+
+func resourceLoadBalancerPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeletePool(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if err := waitPoolDeleted(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Helpers
+
+func expandPoolMember(m map[string]interface{}) cloapi.PoolMemberParams {
+	return cloapi.PoolMemberParams{
+		AddressID:  m["address_id"].(string),
+		Port:       m["port"].(int),
+		Weight:     m["weight"].(int),
+		AdminState: m["admin_state"].(string),
+	}
+}
+
+// findPoolMember returns the member of s with the given hash, or nil.
+func findPoolMember(s *schema.Set, hash int) map[string]interface{} {
+	for _, mem := range s.List() {
+		if poolMemberHash(mem) == hash {
+			return mem.(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+// poolMemberHash identifies a member by its backend address and port, so
+// changing weight or admin_state updates the member in place instead of
+// replacing it.
+func poolMemberHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s|%d", m["address_id"].(string), m["port"].(int)))
+}
+
+// Waiters
+
+// waitPoolSettled waits for a pool or member change to leave the transient
+// CREATING/UPDATING states.
+func waitPoolSettled(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{creatingPool, updatingPool}, []string{activePool}, func() (interface{}, string, error) {
+		p, err := cli.GetPool(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return p, p.Status, nil
+	})
+}
+
+func waitPoolDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	pending := []string{creatingPool, activePool, updatingPool, deletingPool}
+	return waitForState(ctx, timeout, pending, []string{deletedPool}, func() (interface{}, string, error) {
+		p, err := cli.GetPool(ctx, id)
+		if cloapi.IsNotFound(err) {
+			return struct{}{}, deletedPool, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return p, p.Status, nil
+	})
+}
@@ -0,0 +1,164 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const loadBalancerPoolName = "pool_1"
+
+func TestAccCloLoadBalancerPool_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	skipIfNotAcc(t)
+	cli, err := getTestClient()
+	if err != nil {
+		t.Fatal("Error get test client ", err)
+	}
+	lbAddrID, err := buildTestAddress(cli, t)
+	if err != nil {
+		t.Fatal("Error while create address ", err)
+	}
+	serverID, err := buildTestServer(cli, t)
+	if err != nil {
+		t.Fatal("Error while create server ", err)
+	}
+	srv, err := cli.GetServer(context.Background(), serverID)
+	if err != nil {
+		t.Fatal("Error get server ", err)
+	}
+	if len(srv.Addresses) == 0 {
+		t.Fatal("server has no address to add as a pool member")
+	}
+	backendAddrID := srv.Addresses[0]
+
+	pool := new(cloapi.Pool)
+	resName := fmt.Sprintf("clo_network_loadbalancer_pool.%s", loadBalancerPoolName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckLoadBalancerPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloLoadBalancerPoolBasic(lbAddrID, backendAddrID, 1, "ENABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoadBalancerPoolExists(resName, pool),
+					resource.TestCheckResourceAttr(resName, "member.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "member.*", map[string]string{
+						"weight":      "1",
+						"admin_state": "ENABLED",
+					}),
+				),
+			},
+			{
+				// Weight and admin_state change in place: the member keeps its ID.
+				Config: testAccCloLoadBalancerPoolBasic(lbAddrID, backendAddrID, 5, "DRAINING"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoadBalancerPoolMemberKept(resName, pool),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "member.*", map[string]string{
+						"weight":      "5",
+						"admin_state": "DRAINING",
+					}),
+				),
+			},
+			{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCloLoadBalancerPoolBasic(lbAddrID, backendAddrID string, weight int, adminState string) string {
+	return fmt.Sprintf(`resource "clo_network_loadbalancer" "%s" {
+	project_id = "%s"
+	name       = "%s"
+
+	address {
+		id = "%s"
+	}
+
+	healthmonitor {
+		type        = "TCP"
+		delay       = 80
+		timeout     = 15
+		max_retries = 3
+	}
+}
+
+resource "clo_network_loadbalancer_pool" "%s" {
+	loadbalancer_id        = clo_network_loadbalancer.%s.id
+	name                   = "%s"
+	external_protocol_port = 80
+
+	member {
+		address_id  = "%s"
+		port        = 8080
+		weight      = %d
+		admin_state = "%s"
+	}
+}`, loadBalancerName, projectID, loadBalancerName, lbAddrID,
+		loadBalancerPoolName, loadBalancerName, loadBalancerPoolName, backendAddrID, weight, adminState)
+}
+
+func testAccCheckLoadBalancerPoolExists(n string, item *cloapi.Pool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("pool ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		p, e := cli.GetPool(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *p
+		return nil
+	}
+}
+
+// testAccCheckLoadBalancerPoolMemberKept checks that the pool's members still
+// have the IDs recorded in prev, i.e. they were updated rather than replaced.
+func testAccCheckLoadBalancerPoolMemberKept(n string, prev *cloapi.Pool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		cur := new(cloapi.Pool)
+		if e := testAccCheckLoadBalancerPoolExists(n, cur)(state); e != nil {
+			return e
+		}
+		if len(cur.Members) != len(prev.Members) {
+			return fmt.Errorf("member count changed: %d -> %d", len(prev.Members), len(cur.Members))
+		}
+		for i := range cur.Members {
+			if cur.Members[i].ID != prev.Members[i].ID {
+				return fmt.Errorf("member %s was replaced by %s", prev.Members[i].ID, cur.Members[i].ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckLoadBalancerPoolDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_network_loadbalancer_pool" {
+			continue
+		}
+		_, e := cli.GetPool(context.Background(), rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("pool %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
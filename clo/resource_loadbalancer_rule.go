@@ -2,11 +2,13 @@ package clo
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Listener-rule (pool member) statuses, per the cloud_pool_member schema. The
@@ -20,12 +22,28 @@ const (
 	deletedRule  = "DELETED"
 )
 
+// Listener protocols. TCP forwards raw connections; HTTP and HTTPS listeners
+// understand the request and can add X-Forwarded-For, and HTTPS terminates TLS
+// on the balancer with a certificate.
+const (
+	tcpListener   = "TCP"
+	httpListener  = "HTTP"
+	httpsListener = "HTTPS"
+)
+
 func resourceLoadBalancerRule() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Manage a listener rule on a load balancer (maps an external port to an internal port on the backend).",
+		Description:   "Manage a listener rule on a load balancer (maps an external port to an internal port on the backend). `HTTPS` rules terminate TLS on the balancer with a certificate. Listeners other than `TCP` need the provider's `preview_endpoints`. Do not combine with inline `rule` blocks on the same `clo_network_loadbalancer`.",
 		ReadContext:   resourceLoadBalancerRuleRead,
 		CreateContext: resourceLoadBalancerRuleCreate,
 		DeleteContext: resourceLoadBalancerRuleDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			l := expandRuleListener(d.Get)
+			if err := validateRuleListener(l.Protocol, l.CertificateID, d.NewValueKnown("certificate_id"), l.XForwardedFor, l.RedirectHTTPS); err != nil {
+				return err
+			}
+			return requireRuleListenerPreview(m, l)
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -56,6 +74,34 @@ func resourceLoadBalancerRule() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"protocol": {
+				Description:  "Listener protocol. One of `TCP`, `HTTP`, `HTTPS`. Defaults to `TCP`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      tcpListener,
+				ValidateFunc: validation.StringInSlice([]string{tcpListener, httpListener, httpsListener}, false),
+			},
+			"certificate_id": {
+				Description: "ID of the certificate presented to clients. Required for, and only allowed on, `HTTPS` rules.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"x_forwarded_for": {
+				Description: "Add an `X-Forwarded-For` header with the client address. `HTTP` and `HTTPS` rules only.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"redirect_http_to_https": {
+				Description: "Answer every request with a redirect to the same URL over HTTPS instead of forwarding it. `HTTP` rules only.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
 			"id": {
 				Description: "ID of the rule",
 				Type:        schema.TypeString,
@@ -86,6 +132,7 @@ func resourceLoadBalancerRuleCreate(ctx context.Context, d *schema.ResourceData,
 		AddressID:            d.Get("address_id").(string),
 		ExternalProtocolPort: d.Get("external_protocol_port").(int),
 		InternalProtocolPort: d.Get("internal_protocol_port").(int),
+		Listener:             expandRuleListener(d.Get),
 	})
 	if err != nil {
 		return diag.FromErr(err)
@@ -95,7 +142,6 @@ func resourceLoadBalancerRuleCreate(ctx context.Context, d *schema.ResourceData,
 	if err := waitRuleState(ctx, id, cli, []string{creatingRule}, []string{activeRule}, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.FromErr(err)
 	}
-
 	return resourceLoadBalancerRuleRead(ctx, d, m)
 }
 
@@ -109,14 +155,17 @@ func resourceLoadBalancerRuleRead(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	fields := map[string]interface{}{
-		"id":                     r.ID,
-		"status":                 r.Status,
-		"server":                 r.Server,
-		"address":                r.Address,
-		"external_protocol_port": r.ExternalProtocolPort,
-		"internal_protocol_port": r.InternalProtocolPort,
+	l, err := getRuleListener(ctx, cli, r.ID)
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	fields := flattenRuleListener(l)
+	fields["id"] = r.ID
+	fields["status"] = r.Status
+	fields["server"] = r.Server
+	fields["address"] = r.Address
+	fields["external_protocol_port"] = r.ExternalProtocolPort
+	fields["internal_protocol_port"] = r.InternalProtocolPort
 	for k, val := range fields {
 		if e := d.Set(k, val); e != nil {
 			return diag.FromErr(e)
@@ -136,6 +185,64 @@ func resourceLoadBalancerRuleDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// validateRuleListener checks that the listener options fit the protocol.
+// certKnown is false while certificate_id is still unknown at plan time (e.g.
+// it refers to a certificate created in the same apply).
+func validateRuleListener(protocol, certificateID string, certKnown, xForwardedFor, redirectHTTPS bool) error {
+	switch {
+	case protocol == httpsListener && certKnown && certificateID == "":
+		return errors.New("certificate_id is required for HTTPS rules")
+	case protocol != httpsListener && certificateID != "":
+		return errors.New("certificate_id is only allowed on HTTPS rules")
+	case protocol == tcpListener && xForwardedFor:
+		return errors.New("x_forwarded_for requires an HTTP or HTTPS rule")
+	case protocol != httpListener && redirectHTTPS:
+		return errors.New("redirect_http_to_https is only allowed on HTTP rules")
+	}
+	return nil
+}
+
+// expandRuleListener reads the listener options of a rule through get, which
+// is d.Get for the standalone resource or a lookup in an inline rule block.
+func expandRuleListener(get func(string) interface{}) cloapi.RuleListener {
+	return cloapi.RuleListener{
+		Protocol:      get("protocol").(string),
+		CertificateID: get("certificate_id").(string),
+		XForwardedFor: get("x_forwarded_for").(bool),
+		RedirectHTTPS: get("redirect_http_to_https").(bool),
+	}
+}
+
+// requireRuleListenerPreview refuses a listener other than the plain TCP
+// forward while preview endpoints are disabled, since only the preview create
+// can send it.
+func requireRuleListenerPreview(m interface{}, l cloapi.RuleListener) error {
+	if l.IsDefault() {
+		return nil
+	}
+	return requirePreview(m, l.Protocol+" listener")
+}
+
+// getRuleListener returns the rule's listener options. A rule whose listener
+// was never set has none to report and is a plain TCP forward, as is every
+// rule while preview endpoints are disabled, since none could be set then.
+func getRuleListener(ctx context.Context, cli *cloapi.Client, ruleID string) (cloapi.RuleListener, error) {
+	l, err := cli.GetRuleListener(ctx, ruleID)
+	if cloapi.IsNotFound(err) || errors.Is(err, cloapi.ErrPreviewDisabled) {
+		return cloapi.RuleListener{Protocol: tcpListener}, nil
+	}
+	return l, err
+}
+
+func flattenRuleListener(l cloapi.RuleListener) map[string]interface{} {
+	return map[string]interface{}{
+		"protocol":               l.Protocol,
+		"certificate_id":         l.CertificateID,
+		"x_forwarded_for":        l.XForwardedFor,
+		"redirect_http_to_https": l.RedirectHTTPS,
+	}
+}
+
 // Waiters
 
 func waitRuleState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
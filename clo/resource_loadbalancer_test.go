@@ -3,6 +3,7 @@ package clo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
@@ -87,6 +88,199 @@ func TestAccCloLoadBalancerRule_basic(t *testing.T) {
 	})
 }
 
+func TestAccCloLoadBalancer_inlineRules(t *testing.T) {
+	skipIfNotAcc(t)
+	cli, err := getTestClient()
+	if err != nil {
+		t.Fatal("Error get test client ", err)
+	}
+	lbAddrID, err := buildTestAddress(cli, t)
+	if err != nil {
+		t.Fatal("Error while create address ", err)
+	}
+	serverID, err := buildTestServer(cli, t)
+	if err != nil {
+		t.Fatal("Error while create server ", err)
+	}
+	srv, err := cli.GetServer(context.Background(), serverID)
+	if err != nil {
+		t.Fatal("Error get server ", err)
+	}
+	if len(srv.Addresses) == 0 {
+		t.Fatal("server has no address to target with a rule")
+	}
+	backendAddrID := srv.Addresses[0]
+
+	lb := new(cloapi.LoadBalancer)
+	resName := fmt.Sprintf("clo_network_loadbalancer.%s", loadBalancerName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloLoadBalancerInlineRules(lbAddrID, backendAddrID, 80),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoadBalancerExists(resName, lb),
+					resource.TestCheckResourceAttr(resName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(resName, "rules_count", "1"),
+				),
+			},
+			{
+				Config: testAccCloLoadBalancerInlineRules(lbAddrID, backendAddrID, 81),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "rule.*", map[string]string{
+						"external_protocol_port": "81",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateExpectedCodes(t *testing.T) {
+	for _, v := range []string{"200", "200-299", "200,301", "200-204,301,400-499"} {
+		if _, errs := validateExpectedCodes(v, "expected_codes"); len(errs) != 0 {
+			t.Errorf("%q should be valid: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "20", "2000", "abc", "200 ,301", "299-200", "200-", "200,,301", "600"} {
+		if _, errs := validateExpectedCodes(v, "expected_codes"); len(errs) == 0 {
+			t.Errorf("%q should be rejected", v)
+		}
+	}
+}
+
+func TestLoadBalancerHealthmonitorDiff(t *testing.T) {
+	hm := func(fields map[string]interface{}) *terraform.ResourceConfig {
+		monitor := map[string]interface{}{"delay": 80, "timeout": 15, "max_retries": 3}
+		for k, v := range fields {
+			monitor[k] = v
+		}
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id":    "p-1",
+			"name":          "lb",
+			"healthmonitor": []interface{}{monitor},
+		})
+	}
+	cases := []struct {
+		name    string
+		config  *terraform.ResourceConfig
+		wantErr string
+	}{
+		{name: "tcp", config: hm(map[string]interface{}{"type": "TCP"})},
+		{name: "http_full", config: hm(map[string]interface{}{"type": "HTTP", "http_method": "HEAD", "url_path": "/health", "expected_codes": "200-299"})},
+		{name: "tcp_with_url_path", config: hm(map[string]interface{}{"type": "TCP", "url_path": "/health"}), wantErr: "only valid for HTTP monitors"},
+		{name: "ping_with_codes", config: hm(map[string]interface{}{"type": "PING", "expected_codes": "200"}), wantErr: "only valid for HTTP monitors"},
+		{name: "timeout_not_below_delay", config: hm(map[string]interface{}{"type": "TCP", "delay": 80, "timeout": 80}), wantErr: "must be less than"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resourceLoadBalancer().Diff(context.Background(), nil, tc.config, nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerHealthmonitorDefaultsNoDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "lb-1",
+		Attributes: map[string]string{
+			"id":                             "lb-1",
+			"project_id":                     "p-1",
+			"name":                           "lb",
+			"session_persistence":            "false",
+			"enabled":                        "true",
+			"healthmonitor.#":                "1",
+			"healthmonitor.0.type":           "HTTP",
+			"healthmonitor.0.delay":          "80",
+			"healthmonitor.0.timeout":        "15",
+			"healthmonitor.0.max_retries":    "3",
+			"healthmonitor.0.http_method":    defaultMonitorHttpMethod,
+			"healthmonitor.0.url_path":       defaultMonitorUrlPath,
+			"healthmonitor.0.expected_codes": defaultMonitorExpectedCodes,
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": "p-1",
+		"name":       "lb",
+		"healthmonitor": []interface{}{map[string]interface{}{
+			"type": "HTTP", "delay": 80, "timeout": 15, "max_retries": 3,
+		}},
+	})
+	diff, err := resourceLoadBalancer().Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for k, a := range diff.Attributes {
+		if strings.HasPrefix(k, "healthmonitor.") {
+			t.Errorf("API defaults should not diff: %s %q -> %q", k, a.Old, a.New)
+		}
+	}
+}
+
+func TestValidateRuleListener(t *testing.T) {
+	cases := []struct {
+		name      string
+		protocol  string
+		cert      string
+		certKnown bool
+		xff       bool
+		redirect  bool
+		wantErr   bool
+	}{
+		{name: "tcp", protocol: tcpListener, certKnown: true},
+		{name: "https_with_cert", protocol: httpsListener, cert: "c-1", certKnown: true, xff: true},
+		{name: "https_cert_unknown", protocol: httpsListener, certKnown: false},
+		{name: "https_without_cert", protocol: httpsListener, certKnown: true, wantErr: true},
+		{name: "http_with_cert", protocol: httpListener, cert: "c-1", certKnown: true, wantErr: true},
+		{name: "tcp_xff", protocol: tcpListener, certKnown: true, xff: true, wantErr: true},
+		{name: "http_redirect", protocol: httpListener, certKnown: true, redirect: true},
+		{name: "https_redirect", protocol: httpsListener, cert: "c-1", certKnown: true, redirect: true, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRuleListener(tc.protocol, tc.cert, tc.certKnown, tc.xff, tc.redirect)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("wantErr=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerRuleListenerNeedsPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := func(protocol string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"loadbalancer_id":        "lb-1",
+			"address_id":             "addr-1",
+			"external_protocol_port": 80,
+			"internal_protocol_port": 8080,
+			"protocol":               protocol,
+		})
+	}
+	meta := &providerMeta{v3: cli}
+	if _, err := resourceLoadBalancerRule().Diff(context.Background(), nil, rule(tcpListener), meta); err != nil {
+		t.Errorf("a TCP rule needs no preview endpoints, got %v", err)
+	}
+	_, err = resourceLoadBalancerRule().Diff(context.Background(), nil, rule(httpListener), meta)
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("an HTTP rule without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
+
 func testAccCloLoadBalancerBasic(addrID string) string {
 	return fmt.Sprintf(`resource "clo_network_loadbalancer" "%s" {
 	project_id = "%s"
@@ -131,6 +325,30 @@ resource "clo_network_loadbalancer_rule" "%s" {
 }`, loadBalancerName, projectID, loadBalancerName, lbAddrID, loadBalancerRuleName, loadBalancerName, backendAddrID)
 }
 
+func testAccCloLoadBalancerInlineRules(lbAddrID, backendAddrID string, port int) string {
+	return fmt.Sprintf(`resource "clo_network_loadbalancer" "%s" {
+	project_id = "%s"
+	name       = "%s"
+
+	address {
+		id = "%s"
+	}
+
+	healthmonitor {
+		type        = "TCP"
+		delay       = 80
+		timeout     = 15
+		max_retries = 3
+	}
+
+	rule {
+		address_id             = "%s"
+		external_protocol_port = %d
+		internal_protocol_port = 8080
+	}
+}`, loadBalancerName, projectID, loadBalancerName, lbAddrID, backendAddrID, port)
+}
+
 func testAccCheckLoadBalancerExists(n string, item *cloapi.LoadBalancer) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[n]
@@ -0,0 +1,153 @@
+package clo
+
+import (
+	"context"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Private network and subnet lifecycle statuses. ERROR is deliberately absent
+// from every pending set so StateChangeConf fails fast on it.
+const (
+	creatingNetwork = "CREATING"
+	activeNetwork   = "ACTIVE"
+	updatingNetwork = "UPDATING"
+	deletingNetwork = "DELETING"
+	deletedNetwork  = "DELETED"
+)
+
+func resourcePrivateNetwork() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage an isolated private network in the project. Addressing is configured with `clo_network_subnet`; instances join it through `network_interface` blocks on `clo_compute_instance`.",
+		ReadContext:   resourcePrivateNetworkRead,
+		CreateContext: resourcePrivateNetworkCreate,
+		UpdateContext: resourcePrivateNetworkUpdate,
+		DeleteContext: resourcePrivateNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project where the network should be created",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the network. Changing it renames the network in place.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"id": {
+				Description: "ID of the network",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the network",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"subnets": {
+				Description: "IDs of the subnets in the network",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePrivateNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id, err := cli.CreatePrivateNetwork(ctx, d.Get("project_id").(string), d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitPrivateNetworkState(ctx, id, cli, []string{creatingNetwork}, []string{activeNetwork}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePrivateNetworkRead(ctx, d, m)
+}
+
+func resourcePrivateNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	n, err := cli.GetPrivateNetwork(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":         n.ID,
+		"name":       n.Name,
+		"project_id": n.Project,
+		"status":     n.Status,
+		"subnets":    n.Subnets,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourcePrivateNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChange("name") {
+		if err := cli.RenamePrivateNetwork(ctx, d.Id(), d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourcePrivateNetworkRead(ctx, d, m)
+}
+
+func resourcePrivateNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeletePrivateNetwork(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if err := waitPrivateNetworkDeleted(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Waiters
+
+func waitPrivateNetworkState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
+	return waitForState(ctx, timeout, pending, target, func() (interface{}, string, error) {
+		n, err := cli.GetPrivateNetwork(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return n, n.Status, nil
+	})
+}
+
+func waitPrivateNetworkDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{activeNetwork, deletingNetwork}, []string{deletedNetwork}, func() (interface{}, string, error) {
+		n, err := cli.GetPrivateNetwork(ctx, id)
+		if cloapi.IsNotFound(err) {
+			return struct{}{}, deletedNetwork, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return n, n.Status, nil
+	})
+}
@@ -0,0 +1,97 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const privateNetworkName = "net_1"
+
+func TestAccCloPrivateNetwork_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	net := new(cloapi.PrivateNetwork)
+	netRes := fmt.Sprintf("clo_network_private.%s", privateNetworkName)
+	subnetRes := fmt.Sprintf("clo_network_subnet.%s", privateNetworkName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckPrivateNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloPrivateNetworkBasic("8.8.8.8"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPrivateNetworkExists(netRes, net),
+					resource.TestCheckResourceAttr(subnetRes, "cidr", "10.99.0.0/24"),
+					resource.TestCheckResourceAttr(subnetRes, "gateway_ip", "10.99.0.1"),
+					resource.TestCheckResourceAttr(subnetRes, "enable_dhcp", "true"),
+					resource.TestCheckResourceAttr(subnetRes, "dns_nameservers.0", "8.8.8.8"),
+				),
+			},
+			{
+				Config: testAccCloPrivateNetworkBasic("1.1.1.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(subnetRes, "dns_nameservers.0", "1.1.1.1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloPrivateNetworkBasic(dns string) string {
+	return fmt.Sprintf(`resource "clo_network_private" "%[1]s"{
+			project_id = "%[2]s"
+			name       = "%[1]s"
+	}
+	resource "clo_network_subnet" "%[1]s"{
+			network_id      = clo_network_private.%[1]s.id
+			cidr            = "10.99.0.0/24"
+			dns_nameservers = ["%[3]s"]
+	}`, privateNetworkName, projectID, dns)
+}
+
+func testAccCheckPrivateNetworkExists(n string, item *cloapi.PrivateNetwork) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("network ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		net, e := cli.GetPrivateNetwork(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *net
+		return nil
+	}
+}
+
+func testAccCheckPrivateNetworkDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		var e error
+		switch rs.Type {
+		case "clo_network_private":
+			_, e = cli.GetPrivateNetwork(context.Background(), rs.Primary.ID)
+		case "clo_network_subnet":
+			_, e = cli.GetSubnet(context.Background(), rs.Primary.ID)
+		default:
+			continue
+		}
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("%s %s still exists", rs.Type, rs.Primary.ID)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// s3BucketName follows the S3 naming rules: 3 to 63 lower-case letters, digits,
+// dots and hyphens, starting and ending with a letter or digit.
+var s3BucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func resourceS3Bucket() *schema.Resource {
+	return &schema.Resource{
+		Description: "Create a bucket in the object storage. The bucket is managed through the S3 endpoint with the keys " +
+			"of the user that owns it, so it counts against that user's `max_buckets` and quotas.",
+		ReadContext:   resourceS3BucketRead,
+		CreateContext: resourceS3BucketCreate,
+		UpdateContext: resourceS3BucketUpdate,
+		DeleteContext: resourceS3BucketDelete,
+		CustomizeDiff: planS3Endpoint,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: withS3Connection(map[string]*schema.Schema{
+			"bucket": {
+				Description:  "Name of the bucket. Must be unique in the tenant.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(s3BucketName, "must be 3-63 lower-case letters, digits, dots or hyphens, starting and ending with a letter or digit"),
+			},
+			"versioning": {
+				Description: "Keep every version of the bucket's objects. Once enabled, versioning can only be suspended: " +
+					"setting it back to false stops new versions, but the existing ones are kept.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_destroy": {
+				Description: "Delete every object and object version in the bucket when the bucket is destroyed. " +
+					"Without it, destroying a non-empty bucket fails. Applied at destroy time.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"url": {
+				Description: "Path-style URL of the bucket",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		}),
+	}
+}
+
+func resourceS3BucketCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bucket := d.Get("bucket").(string)
+	if err := cli.CreateBucket(ctx, bucket); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(bucket)
+
+	if d.Get("versioning").(bool) {
+		if err := cli.PutBucketVersioning(ctx, bucket, s3api.VersioningEnabled); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceS3BucketRead(ctx, d, m)
+}
+
+func resourceS3BucketRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = cli.HeadBucket(ctx, d.Id())
+	if s3api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status, err := cli.GetBucketVersioning(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"bucket":     d.Id(),
+		"versioning": status == s3api.VersioningEnabled,
+		"url":        strings.TrimRight(s3EndpointFor(d, m), "/") + "/" + d.Id(),
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceS3BucketUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.HasChange("versioning") {
+		status := s3api.VersioningSuspended
+		if d.Get("versioning").(bool) {
+			status = s3api.VersioningEnabled
+		}
+		if err := cli.PutBucketVersioning(ctx, d.Id(), status); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceS3BucketRead(ctx, d, m)
+}
+
+func resourceS3BucketDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("force_destroy").(bool) {
+		if err := emptyS3Bucket(ctx, cli, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	err = cli.DeleteBucket(ctx, d.Id())
+	if s3api.IsNotFound(err) {
+		return nil
+	}
+	if s3api.HasCode(err, "BucketNotEmpty") {
+		return diag.Errorf("bucket %s is not empty; set force_destroy to delete its objects with it", d.Id())
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// emptyS3Bucket deletes every object version and delete marker in the bucket.
+// In a bucket that was never versioned each object has the single version "null".
+func emptyS3Bucket(ctx context.Context, cli *s3api.Client, bucket string) error {
+	versions, err := cli.ListObjectVersions(ctx, bucket)
+	if s3api.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("listing objects of bucket %s: %w", bucket, err)
+	}
+	for _, v := range versions {
+		if err := cli.DeleteObjectVersion(ctx, bucket, v.Key, v.VersionID); err != nil && !s3api.IsNotFound(err) {
+			return fmt.Errorf("deleting %s (version %s) from bucket %s: %w", v.Key, v.VersionID, bucket, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceS3BucketLifecycle() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage the lifecycle rules of a bucket of the object storage: expire objects, drop old versions " +
+			"and abort stale multipart uploads. The rules replace any the bucket already has.",
+		ReadContext:   resourceS3BucketLifecycleRead,
+		CreateContext: resourceS3BucketLifecyclePut,
+		UpdateContext: resourceS3BucketLifecyclePut,
+		DeleteContext: resourceS3BucketLifecycleDelete,
+		CustomizeDiff: planS3Endpoint,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: withS3Connection(map[string]*schema.Schema{
+			"bucket": {
+				Description: "Name of the bucket",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rule": {
+				Description: "Lifecycle rule. Each rule needs at least one of `expiration_days`, " +
+					"`noncurrent_version_expiration_days` or `abort_incomplete_upload_days`.",
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description:  "Unique name of the rule",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 255),
+						},
+						"enabled": {
+							Description: "Whether the rule is applied. Defaults to true.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"prefix": {
+							Description: "Only apply the rule to keys with this prefix. Defaults to the whole bucket.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"expiration_days": {
+							Description:  "Expire objects this many days after they were created",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"noncurrent_version_expiration_days": {
+							Description:  "Delete object versions this many days after they were replaced. Only useful with versioning.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"abort_incomplete_upload_days": {
+							Description:  "Abort multipart uploads not completed this many days after they were started",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func resourceS3BucketLifecyclePut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := buildS3LifecycleRules(d.Get("rule").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bucket := d.Get("bucket").(string)
+	if err := cli.PutBucketLifecycle(ctx, bucket, rules); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(bucket)
+	return resourceS3BucketLifecycleRead(ctx, d, m)
+}
+
+func resourceS3BucketLifecycleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := cli.GetBucketLifecycle(ctx, d.Id())
+	if s3api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if e := d.Set("bucket", d.Id()); e != nil {
+		return diag.FromErr(e)
+	}
+	if e := d.Set("rule", flattenS3LifecycleRules(rules)); e != nil {
+		return diag.FromErr(e)
+	}
+	return nil
+}
+
+func resourceS3BucketLifecycleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.DeleteBucketLifecycle(ctx, d.Id()); err != nil && !s3api.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// buildS3LifecycleRules converts the rule blocks, rejecting duplicate IDs and
+// rules without an action, which S3 refuses with a less helpful message.
+func buildS3LifecycleRules(raw []interface{}) ([]s3api.LifecycleRule, error) {
+	rules := make([]s3api.LifecycleRule, 0, len(raw))
+	seen := map[string]bool{}
+	for _, item := range raw {
+		mr := item.(map[string]interface{})
+		r := s3api.LifecycleRule{
+			ID:                              mr["id"].(string),
+			Enabled:                         mr["enabled"].(bool),
+			Prefix:                          mr["prefix"].(string),
+			ExpirationDays:                  mr["expiration_days"].(int),
+			NoncurrentVersionExpirationDays: mr["noncurrent_version_expiration_days"].(int),
+			AbortIncompleteUploadDays:       mr["abort_incomplete_upload_days"].(int),
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("rule %q: rule IDs must be unique", r.ID)
+		}
+		seen[r.ID] = true
+		if r.ExpirationDays == 0 && r.NoncurrentVersionExpirationDays == 0 && r.AbortIncompleteUploadDays == 0 {
+			return nil, fmt.Errorf("rule %q: set at least one of expiration_days, noncurrent_version_expiration_days or abort_incomplete_upload_days", r.ID)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func flattenS3LifecycleRules(rules []s3api.LifecycleRule) []interface{} {
+	out := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, map[string]interface{}{
+			"id":                                 r.ID,
+			"enabled":                            r.Enabled,
+			"prefix":                             r.Prefix,
+			"expiration_days":                    r.ExpirationDays,
+			"noncurrent_version_expiration_days": r.NoncurrentVersionExpirationDays,
+			"abort_incomplete_upload_days":       r.AbortIncompleteUploadDays,
+		})
+	}
+	return out
+}
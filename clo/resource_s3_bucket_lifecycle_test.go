@@ -0,0 +1,71 @@
+package clo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestS3BucketLifecycleRulesAgainstStandIn(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("logs")
+	meta := s3TestMeta(srv)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceS3BucketLifecycle().Schema, s3TestConfig(map[string]interface{}{
+		"bucket": "logs",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "expire-tmp", "prefix": "tmp/", "expiration_days": 7},
+			map[string]interface{}{"id": "uploads", "enabled": false, "abort_incomplete_upload_days": 2},
+		},
+	}))
+	if diags := resourceS3BucketLifecyclePut(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "logs" {
+		t.Fatalf("id = %q, want logs", d.Id())
+	}
+	rules := d.Get("rule").([]interface{})
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	first, second := rules[0].(map[string]interface{}), rules[1].(map[string]interface{})
+	if first["prefix"] != "tmp/" || first["expiration_days"] != 7 || first["enabled"] != true {
+		t.Errorf("unexpected first rule %v", first)
+	}
+	if second["enabled"] != false || second["abort_incomplete_upload_days"] != 2 {
+		t.Errorf("unexpected second rule %v", second)
+	}
+
+	if diags := resourceS3BucketLifecycleDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if diags := resourceS3BucketLifecycleRead(ctx, d, meta); diags.HasError() || d.Id() != "" {
+		t.Errorf("rules still read after delete: %v, id %q", diags, d.Id())
+	}
+}
+
+func TestBuildS3LifecycleRules(t *testing.T) {
+	rule := func(id string, days int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                                 id,
+			"enabled":                            true,
+			"prefix":                             "",
+			"expiration_days":                    days,
+			"noncurrent_version_expiration_days": 0,
+			"abort_incomplete_upload_days":       0,
+		}
+	}
+	if _, err := buildS3LifecycleRules([]interface{}{rule("a", 1), rule("b", 2)}); err != nil {
+		t.Errorf("valid rules rejected: %v", err)
+	}
+	if _, err := buildS3LifecycleRules([]interface{}{rule("a", 1), rule("a", 2)}); err == nil {
+		t.Error("duplicate rule IDs must be rejected")
+	}
+	if _, err := buildS3LifecycleRules([]interface{}{rule("a", 0)}); err == nil {
+		t.Error("a rule without an action must be rejected")
+	}
+}
@@ -0,0 +1,105 @@
+package clo
+
+import (
+	"context"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceS3BucketPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attach an access policy to a bucket of the object storage. The bucket has a single policy, " +
+			"so only one of these resources should manage each bucket.",
+		ReadContext:   resourceS3BucketPolicyRead,
+		CreateContext: resourceS3BucketPolicyPut,
+		UpdateContext: resourceS3BucketPolicyPut,
+		DeleteContext: resourceS3BucketPolicyDelete,
+		CustomizeDiff: planS3Endpoint,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: withS3Connection(map[string]*schema.Schema{
+			"bucket": {
+				Description: "Name of the bucket",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"policy": {
+				Description:      "Policy document in JSON, e.g. built with `jsonencode`. Formatting and key order changes are ignored.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		}),
+	}
+}
+
+func resourceS3BucketPolicyPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bucket := d.Get("bucket").(string)
+	if err := cli.PutBucketPolicy(ctx, bucket, d.Get("policy").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(bucket)
+	return resourceS3BucketPolicyRead(ctx, d, m)
+}
+
+func resourceS3BucketPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policy, err := cli.GetBucketPolicy(ctx, d.Id())
+	if s3api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Keep the configured text when the server only reformatted it, so the
+	// state matches what the user wrote.
+	if !jsonEquivalent(d.Get("policy").(string), policy) {
+		if e := d.Set("policy", policy); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	if e := d.Set("bucket", d.Id()); e != nil {
+		return diag.FromErr(e)
+	}
+	return nil
+}
+
+func resourceS3BucketPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.DeleteBucketPolicy(ctx, d.Id()); err != nil && !s3api.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// jsonEquivalent reports whether two JSON documents are equal once normalized.
+func jsonEquivalent(a, b string) bool {
+	na, err := structure.NormalizeJsonString(a)
+	if err != nil {
+		return false
+	}
+	nb, err := structure.NormalizeJsonString(b)
+	return err == nil && na == nb
+}
@@ -0,0 +1,180 @@
+package clo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// s3TestMeta is a provider meta whose S3 endpoint is the stand-in. It has no
+// cloapi client, so only the S3 resources can use it.
+func s3TestMeta(srv *s3test.Server) *providerMeta {
+	return &providerMeta{s3Endpoint: srv.URL}
+}
+
+// s3TestConfig adds stand-in credentials to a resource configuration.
+func s3TestConfig(raw map[string]interface{}) map[string]interface{} {
+	raw["access_key"] = "ak"
+	raw["secret_key"] = "sk"
+	return raw
+}
+
+func TestS3BucketLifecycleAgainstStandIn(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	meta := s3TestMeta(srv)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceS3Bucket().Schema, s3TestConfig(map[string]interface{}{
+		"bucket":     "media",
+		"versioning": true,
+	}))
+	if diags := resourceS3BucketCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "media" || !srv.HasBucket("media") {
+		t.Fatalf("bucket not created, id %q", d.Id())
+	}
+	if !d.Get("versioning").(bool) {
+		t.Error("versioning was not enabled")
+	}
+	if got, want := d.Get("url").(string), srv.URL+"/media"; got != want {
+		t.Errorf("url = %q, want %q", got, want)
+	}
+
+	srv.PutObject("media", "a.txt", []byte("a"))
+	if diags := resourceS3BucketDelete(ctx, d, meta); !diags.HasError() {
+		t.Fatal("deleting a non-empty bucket without force_destroy must fail")
+	}
+	if err := d.Set("force_destroy", true); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceS3BucketDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete with force_destroy: %v", diags)
+	}
+	if srv.HasBucket("media") {
+		t.Error("bucket still exists after delete")
+	}
+
+	// A bucket removed outside Terraform drops out of the state.
+	if diags := resourceS3BucketRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q after the bucket was deleted, want empty", d.Id())
+	}
+}
+
+func TestS3BucketNeedsEndpoint(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceS3Bucket().Schema, s3TestConfig(map[string]interface{}{"bucket": "media"}))
+	if diags := resourceS3BucketCreate(context.Background(), d, &providerMeta{}); !diags.HasError() {
+		t.Fatal("expected an error without an endpoint")
+	}
+}
+
+func TestS3BucketEndpointDiff(t *testing.T) {
+	meta := &providerMeta{s3Endpoint: "https://s3.example.com"}
+	cases := []struct {
+		name, old, new string
+		planned        bool
+	}{
+		{"provider endpoint set explicitly", "", "https://s3.example.com/", false},
+		{"explicit endpoint removed", "https://s3.example.com", "", false},
+		{"trailing slash added", "https://other.example.com", "https://other.example.com/", false},
+		{"other endpoint", "", "https://other.example.com", true},
+		{"other endpoint removed", "https://other.example.com", "", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "media",
+				Attributes: map[string]string{
+					"id":            "media",
+					"bucket":        "media",
+					"endpoint":      tc.old,
+					"region":        s3api.DefaultRegion,
+					"access_key":    "ak",
+					"secret_key":    "sk",
+					"versioning":    "false",
+					"force_destroy": "false",
+				},
+			}
+			raw := s3TestConfig(map[string]interface{}{"bucket": "media"})
+			endpoint := cty.NullVal(cty.String)
+			if tc.new != "" {
+				raw["endpoint"] = tc.new
+				endpoint = cty.StringVal(tc.new)
+			}
+			state.RawConfig = rawConfig(resourceS3Bucket(), map[string]cty.Value{"endpoint": endpoint})
+			diff, err := resourceS3Bucket().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff.RequiresNew() {
+				t.Fatal("an endpoint change must not replace the bucket")
+			}
+			if planned := diff != nil && diff.Attributes["endpoint"] != nil; planned != tc.planned {
+				t.Errorf("endpoint change planned = %v, want %v", planned, tc.planned)
+			}
+		})
+	}
+}
+
+func TestS3BucketPolicyAgainstStandIn(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("media")
+	meta := s3TestMeta(srv)
+	ctx := context.Background()
+
+	policy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::media/*"}]}`
+	d := schema.TestResourceDataRaw(t, resourceS3BucketPolicy().Schema, s3TestConfig(map[string]interface{}{
+		"bucket": "media",
+		"policy": policy,
+	}))
+	if diags := resourceS3BucketPolicyPut(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Get("policy").(string) != policy {
+		t.Errorf("policy = %q, want the configured text", d.Get("policy"))
+	}
+
+	// A policy changed outside Terraform shows up as drift.
+	cli, err := s3api.New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.PutBucketPolicy(ctx, "media", `{"Version":"2012-10-17","Statement":[]}`); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceS3BucketPolicyRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("policy").(string) != `{"Version":"2012-10-17","Statement":[]}` {
+		t.Errorf("drifted policy not read back: %q", d.Get("policy"))
+	}
+
+	if diags := resourceS3BucketPolicyDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if diags := resourceS3BucketPolicyRead(ctx, d, meta); diags.HasError() || d.Id() != "" {
+		t.Errorf("policy still read after delete: %v, id %q", diags, d.Id())
+	}
+}
+
+func TestJSONEquivalent(t *testing.T) {
+	if !jsonEquivalent(`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`) {
+		t.Error("reformatted documents must be equivalent")
+	}
+	if jsonEquivalent(`{"b":[2,1]}`, `{"b":[1,2]}`) {
+		t.Error("reordered arrays are not equivalent")
+	}
+	if jsonEquivalent(`{`, `{`) {
+		t.Error("invalid JSON is never equivalent")
+	}
+}
@@ -0,0 +1,267 @@
+package clo
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceS3Object() *schema.Resource {
+	return &schema.Resource{
+		Description: "Upload a small file to a bucket of the object storage, from a local file or inline content. " +
+			"The object is uploaded again when its content, content type or metadata change. Content changes are " +
+			"found by comparing the MD5 of the local content with the object's ETag, so edits made to the object " +
+			"outside Terraform are overwritten as well.",
+		ReadContext:   resourceS3ObjectRead,
+		CreateContext: resourceS3ObjectCreate,
+		UpdateContext: resourceS3ObjectUpdate,
+		DeleteContext: resourceS3ObjectDelete,
+		CustomizeDiff: customdiff.All(planS3Endpoint, planS3ObjectContent),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: withS3Connection(map[string]*schema.Schema{
+			"bucket": {
+				Description: "Name of the bucket",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key": {
+				Description:  "Key to store the object under, e.g. `scripts/bootstrap.sh`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"source": {
+				Description:  "Path of a local file to upload. Exactly one of `source` or `content` is required.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source", "content"},
+			},
+			"content": {
+				Description: "Inline content to upload as UTF-8 text. Exactly one of `source` or `content` is required.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"content_type": {
+				Description: "MIME type of the object. If omitted, it is guessed from the extension of `key` or " +
+					"`source`, and failing that from the content itself.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Description: "User metadata stored with the object and returned as `x-amz-meta-*` headers. " +
+					"Keys must be lower case, as S3 returns them lower-cased.",
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile(`^[a-z0-9_-]+$`), "metadata keys must be lower-case letters, digits, hyphens or underscores"),
+			},
+			"etag": {
+				Description: "ETag of the object: the hex MD5 of its content",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"size": {
+				Description: "Size of the object in bytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"url": {
+				Description: "Path-style URL of the object",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		}),
+	}
+}
+
+func resourceS3ObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := uploadS3Object(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("bucket").(string) + "/" + d.Get("key").(string))
+	return resourceS3ObjectRead(ctx, d, m)
+}
+
+func resourceS3ObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bucket, key := d.Get("bucket").(string), d.Get("key").(string)
+	info, err := cli.HeadObject(ctx, bucket, key)
+	if s3api.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"etag":         info.ETag,
+		"size":         info.Size,
+		"content_type": info.ContentType,
+		"metadata":     info.Metadata,
+		"url":          strings.TrimRight(s3EndpointFor(d, m), "/") + "/" + bucket + "/" + key,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+// resourceS3ObjectUpdate uploads the object again when anything stored with it
+// changed; a new source path with the same content only updates the state.
+func resourceS3ObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges("etag", "content_type", "metadata") {
+		if err := uploadS3Object(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceS3ObjectRead(ctx, d, m)
+}
+
+func resourceS3ObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = cli.DeleteObject(ctx, d.Get("bucket").(string), d.Get("key").(string))
+	if err != nil && !s3api.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// planS3ObjectContent plans an upload when the MD5 of the configured content
+// differs from the object's ETag. A source file that does not exist yet, e.g.
+// one another resource writes during apply, is checked at apply time instead.
+func planS3ObjectContent(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.NewValueKnown("source") && d.NewValueKnown("content") {
+		sum, err := s3ObjectMD5(d.Get("source").(string), d.Get("content").(string))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err == nil && sum == d.Get("etag").(string) {
+			return nil
+		}
+	}
+	if err := d.SetNewComputed("etag"); err != nil {
+		return err
+	}
+	// A guessed content type is guessed again from the new content.
+	if cfg := d.GetRawConfig(); !cfg.IsNull() && cfg.GetAttr("content_type").IsNull() {
+		if err := d.SetNewComputed("content_type"); err != nil {
+			return err
+		}
+	}
+	return d.SetNewComputed("size")
+}
+
+// s3ObjectMD5 is the hex MD5 of the file at source, or of content when source
+// is empty.
+func s3ObjectMD5(source, content string) (string, error) {
+	h := md5.New()
+	if source == "" {
+		h.Write([]byte(content))
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	f, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func uploadS3Object(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cli, err := s3ClientFor(d, m)
+	if err != nil {
+		return err
+	}
+	source, key := d.Get("source").(string), d.Get("key").(string)
+	var (
+		body io.Reader
+		size int64
+	)
+	if source != "" {
+		f, err := os.Open(source)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		body, size = f, fi.Size()
+	} else {
+		content := d.Get("content").(string)
+		body, size = strings.NewReader(content), int64(len(content))
+	}
+
+	// Peek at the start of the body so the content type can be sniffed without
+	// reading the whole file.
+	br := bufio.NewReaderSize(body, 512)
+	contentType := d.Get("content_type").(string)
+	if !configured(d, "content_type") {
+		head, _ := br.Peek(512)
+		contentType = detectS3ContentType(key, source, head)
+	}
+
+	metadata := map[string]string{}
+	for k, v := range d.Get("metadata").(map[string]interface{}) {
+		metadata[k] = v.(string)
+	}
+	_, err = cli.PutObject(ctx, d.Get("bucket").(string), key, br, size, s3api.PutObjectOptions{
+		ContentType: contentType,
+		Metadata:    metadata,
+	})
+	return err
+}
+
+// detectS3ContentType guesses a MIME type from the extension of key, then of
+// source, and finally from the first bytes of the content.
+func detectS3ContentType(key, source string, head []byte) string {
+	for _, ext := range []string{path.Ext(key), filepath.Ext(source)} {
+		if ext == "" {
+			continue
+		}
+		if t := mime.TypeByExtension(ext); t != "" {
+			return t
+		}
+	}
+	return http.DetectContentType(head)
+}
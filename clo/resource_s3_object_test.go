@@ -0,0 +1,145 @@
+package clo
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestS3ObjectAgainstStandIn(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("site")
+	meta := s3TestMeta(srv)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceS3Object().Schema, s3TestConfig(map[string]interface{}{
+		"bucket":   "site",
+		"key":      "index.html",
+		"content":  "<html></html>",
+		"metadata": map[string]interface{}{"build": "42"},
+	}))
+	if diags := resourceS3ObjectCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "site/index.html" {
+		t.Errorf("id = %q, want site/index.html", d.Id())
+	}
+	o := srv.Object("site", "index.html")
+	if o == nil || string(o.Data) != "<html></html>" {
+		t.Fatalf("object not stored: %+v", o)
+	}
+	if o.ContentType != "text/html; charset=utf-8" || o.Metadata["build"] != "42" {
+		t.Errorf("unexpected content type %q or metadata %v", o.ContentType, o.Metadata)
+	}
+	sum := md5.Sum([]byte("<html></html>"))
+	if d.Get("etag").(string) != hex.EncodeToString(sum[:]) || d.Get("size").(int) != 13 {
+		t.Errorf("etag %q, size %d", d.Get("etag"), d.Get("size"))
+	}
+
+	if diags := resourceS3ObjectDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if srv.Object("site", "index.html") != nil {
+		t.Error("object still exists after delete")
+	}
+	if diags := resourceS3ObjectRead(ctx, d, meta); diags.HasError() || d.Id() != "" {
+		t.Errorf("object still read after delete: %v, id %q", diags, d.Id())
+	}
+}
+
+func TestS3ObjectFromSourceSniffsContentType(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("site")
+	source := filepath.Join(t.TempDir(), "logo")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	if err := os.WriteFile(source, png, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceS3Object().Schema, s3TestConfig(map[string]interface{}{
+		"bucket": "site",
+		"key":    "assets/logo",
+		"source": source,
+	}))
+	if diags := resourceS3ObjectCreate(context.Background(), d, s3TestMeta(srv)); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	o := srv.Object("site", "assets/logo")
+	if o == nil || string(o.Data) != string(png) {
+		t.Fatalf("object not stored: %+v", o)
+	}
+	if o.ContentType != "image/png" {
+		t.Errorf("content type = %q, want image/png", o.ContentType)
+	}
+}
+
+func TestS3ObjectContentDiff(t *testing.T) {
+	sum := md5.Sum([]byte("echo v1"))
+	state := &terraform.InstanceState{
+		ID: "scripts/bootstrap.sh",
+		Attributes: map[string]string{
+			"id":           "scripts/bootstrap.sh",
+			"bucket":       "scripts",
+			"key":          "bootstrap.sh",
+			"content":      "echo v1",
+			"content_type": "text/plain",
+			"access_key":   "ak",
+			"secret_key":   "sk",
+			"region":       "us-east-1",
+			"etag":         hex.EncodeToString(sum[:]),
+			"size":         "7",
+		},
+	}
+	config := func(content string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"bucket":     "scripts",
+			"key":        "bootstrap.sh",
+			"content":    content,
+			"access_key": "ak",
+			"secret_key": "sk",
+		})
+	}
+
+	diff, err := resourceS3Object().Diff(context.Background(), state, config("echo v1"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && diff.Attributes["etag"] != nil {
+		t.Errorf("unchanged content must not be uploaded again: %v", diff.Attributes["etag"])
+	}
+
+	diff, err = resourceS3Object().Diff(context.Background(), state, config("echo v2"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil || diff.Attributes["etag"] == nil || !diff.Attributes["etag"].NewComputed {
+		t.Fatalf("changed content must plan a new etag, got %v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Error("a content change must update the object in place")
+	}
+}
+
+func TestDetectS3ContentType(t *testing.T) {
+	cases := []struct {
+		key, source, head, want string
+	}{
+		{"index.html", "", "", "text/html; charset=utf-8"},
+		{"data", "build/data.json", "", "application/json"},
+		{"notes", "", "plain words", "text/plain; charset=utf-8"},
+	}
+	for _, c := range cases {
+		if got := detectS3ContentType(c.key, c.source, []byte(c.head)); got != c.want {
+			t.Errorf("detectS3ContentType(%q, %q) = %q, want %q", c.key, c.source, got, c.want)
+		}
+	}
+}
@@ -3,24 +3,30 @@ package clo
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
-	s3UserActive   = "AVAILABLE"
-	s3UserDelete   = "DELETE"
-	s3UserCreating = "CREATING"
-	s3UserDeleting = "DELETING"
+	s3UserActive     = "AVAILABLE"
+	s3UserDelete     = "DELETE"
+	s3UserCreating   = "CREATING"
+	s3UserDeleting   = "DELETING"
+	s3UserSuspended  = "SUSPENDED"
+	s3UserSuspending = "SUSPENDING"
+	s3UserResuming   = "RESUMING"
 )
 
 func resourceS3User() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Create a new user of the object storage",
+		Description:   "Create a new user of the object storage. Set `enabled` to false to suspend the user's access while keeping their buckets and data; suspending and resuming need the provider's `preview_endpoints`.",
 		ReadContext:   resourceS3UserRead,
 		CreateContext: resourceS3UserCreate,
 		UpdateContext: resourceS3UserUpdate,
@@ -31,67 +37,124 @@ func resourceS3User() *schema.Resource {
 			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
-		Schema: map[string]*schema.Schema{
-			"project_id": {
-				Description: "ID of the project where the user should be created",
-				Type:        schema.TypeString,
-				Required:    true,
+		Schema:        resourceS3UserSchema(),
+		CustomizeDiff: customdiff.All(validateS3BucketQuota, validateS3UserEnabled),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceS3UserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeS3UserStateV0,
 			},
-			"canonical_name": {
-				Description: "Canonical name of the user. The storage uses this name. Should be unique in scope of the tenant",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			"default_bucket": {
-				Description: "Should the default bucket be created with the user",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				ForceNew:    true,
-			},
-			"max_buckets": {
-				Description: "How many buckets the user could create",
-				Type:        schema.TypeInt,
-				Required:    true,
-			},
-			"name": {
-				Description: "Human-readable name of the user",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
-			"user_quota_max_size": {
-				Description: "Total size of the objects the user can store",
-				Type:        schema.TypeInt,
-				Required:    true,
-			},
-			"user_quota_max_objects": {
-				Description: "How many objects the user can create",
-				Type:        schema.TypeInt,
-				Optional:    true,
-			},
-			"bucket_quota_max_size": {
-				Description: "A maximum size of a bucket",
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-			},
-			"bucket_quota_max_objects": {
-				Description: "How many objects can be created within a bucket",
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-			},
-			"user_id": {
-				Description: "ID of the created user",
-				Type:        schema.TypeString, Computed: true},
-			"status": {Type: schema.TypeString, Computed: true},
-			"tenant": {
-				Description: "Name of the user's tenant. Name of the user's project uses by default",
-				Type:        schema.TypeString, Computed: true},
 		},
 	}
 }
 
+func resourceS3UserSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Description: "ID of the project where the user should be created",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"canonical_name": {
+			Description: "Canonical name of the user. The storage uses this name. Should be unique in scope of the tenant",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"default_bucket": {
+			Description: "Should the default bucket be created with the user",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"max_buckets": {
+			Description: "How many buckets the user could create",
+			Type:        schema.TypeInt,
+			Required:    true,
+		},
+		"name": {
+			Description: "Human-readable name of the user",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"user_quota_max_size": {
+			Description: "Total size of the objects the user can store, e.g. `500GiB` or `1.5TiB`. " +
+				"Sizes must be whole GiB; a bare number is read as GiB.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateS3QuotaSize,
+			StateFunc:    normalizeS3QuotaSize,
+		},
+		"user_quota_max_objects": {
+			Description: "How many objects the user can create",
+			Type:        schema.TypeInt,
+			Optional:    true,
+		},
+		"bucket_quota_max_size": {
+			Description: "A maximum size of a bucket, in the same format as `user_quota_max_size`. " +
+				"Cannot exceed `user_quota_max_size`. `0` means no limit.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "0",
+			ValidateFunc: validateS3QuotaSize,
+			StateFunc:    normalizeS3QuotaSize,
+		},
+		"bucket_quota_max_objects": {
+			Description: "How many objects can be created within a bucket. Cannot exceed `user_quota_max_objects`.",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+		},
+		"enabled": {
+			Description: "Whether the user can access the storage. Setting it to false suspends the user in place: " +
+				"requests with their keys are refused, but their buckets and objects are kept. Defaults to true.",
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"user_id": {
+			Description: "ID of the created user",
+			Type:        schema.TypeString, Computed: true},
+		"status": {
+			Description: "Lifecycle status of the user, e.g. `" + s3UserActive + "` or `" + s3UserSuspended + "`",
+			Type:        schema.TypeString, Computed: true},
+		"tenant": {
+			Description: "Name of the user's tenant. Name of the user's project uses by default",
+			Type:        schema.TypeString, Computed: true},
+	}
+}
+
+// resourceS3UserV0 is the schema from before quota sizes took units, when both
+// sizes were integers counted in GiB.
+func resourceS3UserV0() *schema.Resource {
+	s := resourceS3UserSchema()
+	for _, k := range []string{"user_quota_max_size", "bucket_quota_max_size"} {
+		s[k] = &schema.Schema{Type: schema.TypeInt, Optional: true}
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// upgradeS3UserStateV0 stores the integer quota sizes in the normalized form
+// the sizes are kept in now, so upgrading plans no change.
+func upgradeS3UserStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	for _, k := range []string{"user_quota_max_size", "bucket_quota_max_size"} {
+		switch v := rawState[k].(type) {
+		case nil:
+		case float64:
+			rawState[k] = formatS3QuotaSize(int(v))
+		case int:
+			rawState[k] = formatS3QuotaSize(v)
+		case string:
+			rawState[k] = normalizeS3QuotaSize(v)
+		default:
+			return nil, fmt.Errorf("%s: unexpected value %v in state", k, v)
+		}
+	}
+	return rawState, nil
+}
+
 func resourceS3UserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
 
@@ -105,6 +168,13 @@ func resourceS3UserCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	// A new user is active; only act if the user asked for it suspended.
+	if !d.Get("enabled").(bool) {
+		if err := setS3UserEnabled(ctx, id, cli, false, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceS3UserRead(ctx, d, m)
 }
 
@@ -115,9 +185,9 @@ func buildS3UserCreateParams(d *schema.ResourceData) cloapi.S3UserCreateParams {
 		CanonicalName:         d.Get("canonical_name").(string),
 		DefaultBucket:         d.Get("default_bucket").(bool),
 		MaxBuckets:            d.Get("max_buckets").(int),
-		UserQuotaMaxSize:      d.Get("user_quota_max_size").(int),
+		UserQuotaMaxSize:      s3QuotaSize(d.Get("user_quota_max_size").(string)),
 		UserQuotaMaxObjects:   d.Get("user_quota_max_objects").(int),
-		BucketQuotaMaxSize:    d.Get("bucket_quota_max_size").(int),
+		BucketQuotaMaxSize:    s3QuotaSize(d.Get("bucket_quota_max_size").(string)),
 		BucketQuotaMaxObjects: d.Get("bucket_quota_max_objects").(int),
 	}
 }
@@ -132,6 +202,12 @@ func resourceS3UserUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
+	if d.HasChange("enabled") {
+		if err := setS3UserEnabled(ctx, uId, cli, d.Get("enabled").(bool), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChanges(
 		"max_buckets",
 		"user_quota_max_size",
@@ -139,20 +215,36 @@ func resourceS3UserUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		"bucket_quota_max_size",
 		"bucket_quota_max_objects",
 	) {
-		err := cli.UpdateS3UserQuota(ctx, uId, cloapi.S3UserQuotaParams{
-			MaxBuckets:            d.Get("max_buckets").(int),
-			UserQuotaMaxSize:      d.Get("user_quota_max_size").(int),
-			UserQuotaMaxObjects:   d.Get("user_quota_max_objects").(int),
-			BucketQuotaMaxSize:    d.Get("bucket_quota_max_size").(int),
-			BucketQuotaMaxObjects: d.Get("bucket_quota_max_objects").(int),
-		})
-		if err != nil {
+		if err := cli.UpdateS3UserQuota(ctx, uId, buildS3UserQuotaParams(d)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 	return resourceS3UserRead(ctx, d, m)
 }
 
+// buildS3UserQuotaParams only includes the quota types that changed, so quotas
+// set outside Terraform for the other types are left alone.
+func buildS3UserQuotaParams(d *schema.ResourceData) cloapi.S3UserQuotaParams {
+	var p cloapi.S3UserQuotaParams
+	if d.HasChange("max_buckets") {
+		maxBuckets := d.Get("max_buckets").(int)
+		p.MaxBuckets = &maxBuckets
+	}
+	if d.HasChanges("user_quota_max_size", "user_quota_max_objects") {
+		p.UserQuota = &cloapi.S3QuotaLimits{
+			MaxSize:    s3QuotaSize(d.Get("user_quota_max_size").(string)),
+			MaxObjects: d.Get("user_quota_max_objects").(int),
+		}
+	}
+	if d.HasChanges("bucket_quota_max_size", "bucket_quota_max_objects") {
+		p.BucketQuota = &cloapi.S3QuotaLimits{
+			MaxSize:    s3QuotaSize(d.Get("bucket_quota_max_size").(string)),
+			MaxObjects: d.Get("bucket_quota_max_objects").(int),
+		}
+	}
+	return p
+}
+
 func resourceS3UserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
 	user, err := cli.GetS3User(ctx, d.Id())
@@ -165,6 +257,9 @@ func resourceS3UserRead(ctx context.Context, d *schema.ResourceData, m interface
 	if e := d.Set("status", user.Status); e != nil {
 		return diag.FromErr(e)
 	}
+	if e := d.Set("enabled", s3UserEnabled(user.Status)); e != nil {
+		return diag.FromErr(e)
+	}
 	if e := d.Set("tenant", user.Tenant); e != nil {
 		return diag.FromErr(e)
 	}
@@ -178,14 +273,14 @@ func dispatchQuotaInfo(d *schema.ResourceData, q []cloapi.S3Quota) diag.Diagnost
 	for _, qi := range q {
 		switch strings.ToLower(qi.Type) {
 		case "user":
-			if e := d.Set("user_quota_max_size", qi.MaxSize); e != nil {
+			if e := d.Set("user_quota_max_size", formatS3QuotaSize(qi.MaxSize)); e != nil {
 				return diag.FromErr(e)
 			}
 			if e := d.Set("user_quota_max_objects", qi.MaxObjects); e != nil {
 				return diag.FromErr(e)
 			}
 		case "bucket":
-			if e := d.Set("bucket_quota_max_size", qi.MaxSize); e != nil {
+			if e := d.Set("bucket_quota_max_size", formatS3QuotaSize(qi.MaxSize)); e != nil {
 				return diag.FromErr(e)
 			}
 			if e := d.Set("bucket_quota_max_objects", qi.MaxObjects); e != nil {
@@ -209,6 +304,134 @@ func resourceS3UserDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// validateS3BucketQuota rejects bucket limits above the user's own, which the
+// user could never reach.
+func validateS3BucketQuota(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	limits := []struct{ bucket, user string }{
+		{"bucket_quota_max_size", "user_quota_max_size"},
+		{"bucket_quota_max_objects", "user_quota_max_objects"},
+	}
+	for _, l := range limits {
+		if !d.NewValueKnown(l.bucket) || !d.NewValueKnown(l.user) {
+			continue
+		}
+		bucket, user := s3QuotaLimit(d.Get(l.bucket)), s3QuotaLimit(d.Get(l.user))
+		if bucket > 0 && user > 0 && bucket > user {
+			return fmt.Errorf("%s (%v) cannot exceed %s (%v)", l.bucket, d.Get(l.bucket), l.user, d.Get(l.user))
+		}
+	}
+	return nil
+}
+
+// validateS3UserEnabled refuses to suspend or resume the user while preview
+// endpoints are disabled, since only the preview endpoints can do either.
+func validateS3UserEnabled(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" && d.Get("enabled").(bool) || d.Id() != "" && !d.HasChange("enabled") {
+		return nil
+	}
+	return requirePreview(m, "enabled")
+}
+
+// s3QuotaLimit reads a quota size or object count as a number.
+func s3QuotaLimit(v interface{}) int {
+	if s, ok := v.(string); ok {
+		return s3QuotaSize(s)
+	}
+	return v.(int)
+}
+
+// The API counts quota sizes in GiB.
+var s3QuotaSizeUnits = []struct {
+	suffix string
+	gib    float64
+}{
+	{"PiB", 1 << 20},
+	{"TiB", 1 << 10},
+	{"GiB", 1},
+	{"MiB", 1.0 / (1 << 10)},
+	{"KiB", 1.0 / (1 << 20)},
+}
+
+// parseS3QuotaSize parses a size such as "500GiB", "1.5 TiB" or a bare number
+// of GiB, and returns it in GiB.
+func parseS3QuotaSize(s string) (int, error) {
+	num, gib := strings.TrimSpace(s), 1.0
+	for _, u := range s3QuotaSizeUnits {
+		if len(num) > len(u.suffix) && strings.EqualFold(num[len(num)-len(u.suffix):], u.suffix) {
+			num, gib = strings.TrimSpace(num[:len(num)-len(u.suffix)]), u.gib
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("invalid size %q, expected e.g. 500GiB or 2TiB", s)
+	}
+	size := n * gib
+	if size != math.Trunc(size) {
+		return 0, fmt.Errorf("size %q is not a whole number of GiB", s)
+	}
+	if size > math.MaxInt32 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int(size), nil
+}
+
+// formatS3QuotaSize writes a size in GiB in the largest unit that divides it.
+func formatS3QuotaSize(gib int) string {
+	if gib == 0 {
+		return "0"
+	}
+	for _, u := range s3QuotaSizeUnits {
+		if u.gib >= 1 && gib%int(u.gib) == 0 {
+			return strconv.Itoa(gib/int(u.gib)) + u.suffix
+		}
+	}
+	return strconv.Itoa(gib) + "GiB"
+}
+
+// s3QuotaSize is parseS3QuotaSize for values that passed validation.
+func s3QuotaSize(s string) int {
+	gib, _ := parseS3QuotaSize(s)
+	return gib
+}
+
+func validateS3QuotaSize(v interface{}, k string) ([]string, []error) {
+	if _, err := parseS3QuotaSize(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// normalizeS3QuotaSize stores sizes in one canonical form, so "1TiB" and
+// "1024" plan no change against each other.
+func normalizeS3QuotaSize(v interface{}) string {
+	gib, err := parseS3QuotaSize(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return formatS3QuotaSize(gib)
+}
+
+// s3UserEnabled reports whether a user in status can access the storage. A
+// user on the way to suspension counts as suspended already.
+func s3UserEnabled(status string) bool {
+	return status != s3UserSuspended && status != s3UserSuspending
+}
+
+// setS3UserEnabled suspends or resumes the user and waits for it to settle.
+func setS3UserEnabled(ctx context.Context, id string, cli *cloapi.Client, enabled bool, timeout time.Duration) error {
+	if enabled {
+		if err := cli.ResumeS3User(ctx, id); err != nil {
+			return err
+		}
+		return waitS3UserState(ctx, id, cli, []string{s3UserSuspended, s3UserResuming}, []string{s3UserActive}, timeout)
+	}
+	if err := cli.SuspendS3User(ctx, id); err != nil {
+		return err
+	}
+	return waitS3UserState(ctx, id, cli, []string{s3UserActive, s3UserSuspending}, []string{s3UserSuspended}, timeout)
+}
+
 // waiters
 
 func waitS3UserState(ctx context.Context, id string, cli *cloapi.Client, pending []string, target []string, timeout time.Duration) error {
@@ -2,17 +2,26 @@ package clo
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceS3UserKeys() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Create key's pairs for the user",
+		Description: "Create a key pair for the user. A user can hold several pairs, each managed by its own resource. " +
+			"Change `rotation` to issue a new pair; the current pair stays valid as `previous_access_key` until the " +
+			"next rotation, so consumers can move over, and is revoked then or when the resource is destroyed. A pair " +
+			"that is revoked or regenerated outside Terraform is created again on the next apply. Without the provider's " +
+			"`preview_endpoints`, the user's default pair is issued instead: it replaces the pair the user had, and changing " +
+			"`rotation` is refused.",
 		ReadContext:   resourceS3UserKeysRead,
 		CreateContext: resourceS3UserKeysCreate,
+		UpdateContext: resourceS3UserKeysUpdate,
 		DeleteContext: resourceS3UserKeysDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -27,51 +36,235 @@ func resourceS3UserKeys() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
-			"access_key": {
+			"rotation": {
+				Description: "Arbitrary value; changing it issues a new key pair, keeps the current one as the previous " +
+					"pair and revokes the pair that was previous until then, e.g. a date or a `time_rotating` ID.",
 				Type:     schema.TypeString,
-				Computed: true,
-				ForceNew: true,
+				Optional: true,
+			},
+			"access_key": {
+				Description: "Access key of the pair",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"secret_key": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Sensitive: true,
-				ForceNew:  true,
+				Description: "Secret key of the pair. Only known when the pair is issued.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"created_in": {
+				Description: "Timestamp the pair was issued",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_key_id": {
+				Description: "ID of the pair the last rotation replaced, which stays valid until the next rotation",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_access_key": {
+				Description: "Access key of the pair the last rotation replaced. Its secret key is the `secret_key` " +
+					"consumers held before the rotation.",
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
+		CustomizeDiff: planS3UserKeysRotation,
 	}
 }
 
 func resourceS3UserKeysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
 	uId := d.Get("user_id").(string)
+	if !cli.PreviewEnabled() {
+		// Only the user's default pair can be issued without the key pair
+		// endpoints; it is stored under the user's ID like pairs from before
+		// key pairs had IDs.
+		keys, err := cli.GenS3UserKeys(ctx, uId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(uId)
+		if e := d.Set("access_key", keys.AccessKey); e != nil {
+			return diag.FromErr(e)
+		}
+		if e := d.Set("secret_key", keys.SecretKey); e != nil {
+			return diag.FromErr(e)
+		}
+		return resourceS3UserKeysRead(ctx, d, m)
+	}
+	key, err := cli.CreateS3UserKey(ctx, uId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setS3UserKey(d, key); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceS3UserKeysRead(ctx, d, m)
+}
+
+func resourceS3UserKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	cli := m.(*providerMeta).v3
-	keys, err := cli.GenS3UserKeys(ctx, uId)
+	uId := d.Get("user_id").(string)
+	stateKey := d.Get("access_key").(string)
+
+	// Pairs created before key pairs had their own IDs are stored under the
+	// user's ID, and are the user's single default pair.
+	if d.Id() == uId {
+		accessKey, err := cli.GetS3UserAccessKey(ctx, uId)
+		if cloapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if stateKey != "" && accessKey != stateKey {
+			log.Printf("[WARN] access key of s3 user %s was regenerated outside Terraform, removing the pair from state", uId)
+			d.SetId("")
+		}
+		return nil
+	}
+
+	// Each pair is listed with its own access key, so a pair regenerated outside
+	// Terraform shows up here as a changed access key. GetS3UserAccessKey only
+	// knows the user's default pair and cannot tell the pairs apart.
+	keys, err := cli.ListS3UserKeys(ctx, uId)
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(uId)
-	if e := d.Set("access_key", keys.AccessKey); e != nil {
-		return diag.FromErr(e)
+	key := findS3Key(keys, d.Id())
+	if key == nil {
+		log.Printf("[WARN] key pair %s of s3 user %s was revoked outside Terraform, removing it from state", d.Id(), uId)
+		d.SetId("")
+		return nil
 	}
-	if e := d.Set("secret_key", keys.SecretKey); e != nil {
-		return diag.FromErr(e)
+	// The secret key we hold no longer matches if the pair was regenerated.
+	if stateKey != "" && key.AccessKey != stateKey {
+		log.Printf("[WARN] key pair %s of s3 user %s was regenerated outside Terraform, removing it from state", d.Id(), uId)
+		d.SetId("")
+		return nil
+	}
+	fields := map[string]interface{}{
+		"access_key": key.AccessKey,
+		"created_in": key.CreatedIn,
+	}
+	// The previous pair may have been revoked outside Terraform already.
+	if prevID := d.Get("previous_key_id").(string); prevID != "" && findS3Key(keys, prevID) == nil {
+		fields["previous_key_id"] = ""
+		fields["previous_access_key"] = ""
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
 	}
 	return nil
 }
 
-func resourceS3UserKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// resourceS3UserKeysUpdate rotates the pair: the new pair is issued, the
+// current one is kept as the previous pair so consumers can still use it until
+// they move over, and the pair that was previous until now is revoked.
+func resourceS3UserKeysUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChange("rotation") {
+		return resourceS3UserKeysRead(ctx, d, m)
+	}
 	cli := m.(*providerMeta).v3
-	accessKey, err := cli.GetS3UserAccessKey(ctx, d.Id())
+	uId := d.Get("user_id").(string)
+	curID, curAccessKey := d.Id(), d.Get("access_key").(string)
+	if curID == uId {
+		// A pair from before key pairs had IDs; look its ID up so it can be
+		// revoked at the next rotation.
+		keys, err := cli.ListS3UserKeys(ctx, uId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		curID = ""
+		for _, k := range keys {
+			if k.AccessKey == curAccessKey {
+				curID = k.ID
+			}
+		}
+	}
+
+	// The new pair is issued before anything is revoked, so a failure leaves
+	// the pairs consumers hold valid.
+	prevID := d.Get("previous_key_id").(string)
+	key, err := cli.CreateS3UserKey(ctx, uId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	// Only the access key is returned on read; the secret key (set at create) is left as-is.
-	if e := d.Set("access_key", accessKey); e != nil {
+	if err := setS3UserKey(d, key); err != nil {
+		return diag.FromErr(err)
+	}
+	if e := d.Set("previous_key_id", curID); e != nil {
 		return diag.FromErr(e)
 	}
-	return nil
+	if e := d.Set("previous_access_key", curAccessKey); e != nil {
+		return diag.FromErr(e)
+	}
+	if prevID != "" {
+		if err := cli.RevokeS3UserKey(ctx, prevID); err != nil && !cloapi.IsNotFound(err) {
+			return diag.FromErr(fmt.Errorf("revoking the key pair %s the rotation before kept: %w; it stays valid until revoked", prevID, err))
+		}
+	}
+	return resourceS3UserKeysRead(ctx, d, m)
 }
 
 func resourceS3UserKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if prevID := d.Get("previous_key_id").(string); prevID != "" {
+		if err := cli.RevokeS3UserKey(ctx, prevID); err != nil && !cloapi.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+	// The default pair of a user from before key pairs had IDs cannot be revoked
+	// on its own; it goes away with the user.
+	if d.Id() == d.Get("user_id").(string) {
+		return nil
+	}
+	if err := cli.RevokeS3UserKey(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// planS3UserKeysRotation marks the keys as changing when a rotation is planned.
+// Rotating needs the key pair endpoints, so it is refused while preview
+// endpoints are disabled.
+func planS3UserKeysRotation(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("rotation") {
+		return nil
+	}
+	if err := requirePreview(m, "rotation"); err != nil {
+		return err
+	}
+	for _, k := range []string{"access_key", "secret_key", "created_in", "previous_key_id", "previous_access_key"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setS3UserKey(d *schema.ResourceData, key *cloapi.S3Key) error {
+	d.SetId(key.ID)
+	if e := d.Set("access_key", key.AccessKey); e != nil {
+		return e
+	}
+	return d.Set("secret_key", key.SecretKey)
+}
+
+func findS3Key(keys []cloapi.S3Key, id string) *cloapi.S3Key {
+	for i := range keys {
+		if keys[i].ID == id {
+			return &keys[i]
+		}
+	}
 	return nil
 }
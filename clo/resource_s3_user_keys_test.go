@@ -3,8 +3,10 @@ package clo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -28,7 +30,7 @@ func TestAccCloS3UserKeys_basic(t *testing.T) {
 			{
 				Config: testAccCloS3KeysBasic(userId),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckS3KeysExists("clo_storage_s3_user_keys.test_keys", userId),
+					testAccCheckS3KeysExists("clo_storage_s3_user_keys.test_keys"),
 				),
 			},
 		},
@@ -36,23 +38,76 @@ func TestAccCloS3UserKeys_basic(t *testing.T) {
 
 }
 
+func TestAccCloS3UserKeys_rotation(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotPreview(t)
+	cli, err := getTestClient()
+	if err != nil {
+		t.Error("Error get test client ", err)
+	}
+
+	userId, err := buildTestS3user(cli, t)
+	if err != nil {
+		t.Error("Error while create s3 user ", err)
+	}
+
+	var first, second, retired string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloS3KeysRotation(userId, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3KeyPairExists("clo_storage_s3_user_keys.test_keys", &first),
+					testAccCheckS3KeyPairExists("clo_storage_s3_user_keys.second_keys", &second),
+				),
+			},
+			{
+				// Rotating the first pair leaves the second one alone.
+				Config: testAccCloS3KeysRotation(userId, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3KeysRotated("clo_storage_s3_user_keys.test_keys", &first, &retired),
+					testAccCheckS3KeyPairExists("clo_storage_s3_user_keys.second_keys", &second),
+				),
+			},
+			{
+				// The next rotation revokes the pair the first one kept.
+				Config: testAccCloS3KeysRotation(userId, "3"),
+				Check:  testAccCheckS3KeysRotated("clo_storage_s3_user_keys.test_keys", &first, &retired),
+			},
+		},
+	})
+
+}
+
 func testAccCloS3KeysBasic(userId string) string {
 	return fmt.Sprintf(`resource "clo_storage_s3_user_keys" "test_keys"{
 			user_id = "%s"
 	}`, userId)
 }
 
-func testAccCheckS3KeysExists(n string, serverId string) resource.TestCheckFunc {
+func testAccCloS3KeysRotation(userId, rotation string) string {
+	return fmt.Sprintf(`resource "clo_storage_s3_user_keys" "test_keys"{
+			user_id  = "%s"
+			rotation = "%s"
+	}
+	resource "clo_storage_s3_user_keys" "second_keys"{
+			user_id = "%s"
+	}`, userId, rotation, userId)
+}
+
+func testAccCheckS3KeysExists(n string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[n]
 		if !ok {
 			return fmt.Errorf("not found: %s", n)
 		}
 		if rs.Primary.ID == "" {
-			return fmt.Errorf("volume with ID is not set")
+			return fmt.Errorf("key pair ID is not set")
 		}
 		cli := testAccProvider.Meta().(*providerMeta).v3
-		accessKey, e := cli.GetS3UserAccessKey(context.Background(), rs.Primary.ID)
+		accessKey, e := cli.GetS3UserAccessKey(context.Background(), rs.Primary.Attributes["user_id"])
 		if e != nil {
 			return e
 		}
@@ -62,3 +117,139 @@ func testAccCheckS3KeysExists(n string, serverId string) resource.TestCheckFunc
 		return nil
 	}
 }
+
+// testAccCheckS3KeyPairExists checks that the pair is one of the user's pairs
+// and records its ID.
+func testAccCheckS3KeyPairExists(n string, keyID *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("key pair ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		keys, e := cli.ListS3UserKeys(context.Background(), rs.Primary.Attributes["user_id"])
+		if e != nil {
+			return e
+		}
+		key := findS3Key(keys, rs.Primary.ID)
+		if key == nil || key.AccessKey != rs.Primary.Attributes["access_key"] {
+			return fmt.Errorf("key pair %s is not one of the user's pairs", rs.Primary.ID)
+		}
+		if *keyID != "" && *keyID != rs.Primary.ID {
+			return fmt.Errorf("key pair changed from %s to %s", *keyID, rs.Primary.ID)
+		}
+		*keyID = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckS3KeysRotated checks that the pair was replaced by a new one,
+// that the replaced pair is kept as the previous pair and that the pair kept
+// by the rotation before (retired) was revoked.
+func testAccCheckS3KeysRotated(n string, keyID, retired *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == *keyID {
+			return fmt.Errorf("key pair %s was not rotated", *keyID)
+		}
+		if prev := rs.Primary.Attributes["previous_key_id"]; prev != *keyID {
+			return fmt.Errorf("previous_key_id = %s, want the replaced pair %s", prev, *keyID)
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		keys, e := cli.ListS3UserKeys(context.Background(), rs.Primary.Attributes["user_id"])
+		if e != nil {
+			return e
+		}
+		if findS3Key(keys, *keyID) == nil {
+			return fmt.Errorf("previous key pair %s was revoked before the next rotation", *keyID)
+		}
+		if *retired != "" && findS3Key(keys, *retired) != nil {
+			return fmt.Errorf("key pair %s from two rotations ago was not revoked", *retired)
+		}
+		if findS3Key(keys, rs.Primary.ID) == nil {
+			return fmt.Errorf("new key pair %s is not one of the user's pairs", rs.Primary.ID)
+		}
+		*retired, *keyID = *keyID, rs.Primary.ID
+		return nil
+	}
+}
+
+func TestFindS3Key(t *testing.T) {
+	keys := []cloapi.S3Key{{ID: "k-1", AccessKey: "AK1"}, {ID: "k-2", AccessKey: "AK2"}}
+	if k := findS3Key(keys, "k-2"); k == nil || k.AccessKey != "AK2" {
+		t.Errorf("findS3Key(k-2) = %+v", k)
+	}
+	if k := findS3Key(keys, "k-3"); k != nil {
+		t.Errorf("findS3Key(k-3) = %+v, want nil", k)
+	}
+}
+
+func TestS3UserKeysRotationDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "k-1",
+		Attributes: map[string]string{
+			"id":         "k-1",
+			"user_id":    "u-1",
+			"rotation":   "2026-10",
+			"access_key": "AK1",
+			"secret_key": "SK1",
+			"created_in": "2026-10-01T00:00:00Z",
+		},
+	}
+	config := func(rotation string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{"user_id": "u-1", "rotation": rotation})
+	}
+
+	diff, err := resourceS3UserKeys().Diff(context.Background(), state, config("2026-10"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("no rotation planned, got %v", diff)
+	}
+
+	cli, err := cloapi.New("token", "https://api.example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err = resourceS3UserKeys().Diff(context.Background(), state, config("2026-11"), &providerMeta{v3: cli})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil || diff.Attributes["access_key"] == nil || !diff.Attributes["access_key"].NewComputed {
+		t.Fatalf("rotation must plan a new access key, got %v", diff)
+	}
+	if a := diff.Attributes["previous_access_key"]; a == nil || !a.NewComputed {
+		t.Errorf("rotation must plan a new previous pair, got %v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Error("rotation must update the pair in place")
+	}
+}
+
+func TestS3UserKeysRotationNeedsPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &terraform.InstanceState{
+		ID: "u-1",
+		Attributes: map[string]string{
+			"id":         "u-1",
+			"user_id":    "u-1",
+			"rotation":   "2026-10",
+			"access_key": "AK1",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"user_id": "u-1", "rotation": "2026-11"})
+	_, err = resourceS3UserKeys().Diff(context.Background(), state, config, &providerMeta{v3: cli})
+	if err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("rotation without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
@@ -23,7 +24,7 @@ func TestAccCloS3User_basic(t *testing.T) {
 		CheckDestroy:      testAccCheckS3UserDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloS3UserBasic(),
+				Config: testAccCloS3UserBasic(true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckS3UserExists(fmt.Sprintf("clo_storage_s3_user.%s", userName), s3User),
 				),
@@ -32,13 +33,59 @@ func TestAccCloS3User_basic(t *testing.T) {
 	})
 }
 
-func testAccCloS3UserBasic() string {
+func TestAccCloS3User_suspend(t *testing.T) {
+	skipIfNotPreview(t)
+	var s3User = new(cloapi.S3User)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckS3UserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloS3UserBasic(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckS3UserExists(fmt.Sprintf("clo_storage_s3_user.%s", userName), s3User),
+				),
+			},
+			{
+				Config: testAccCloS3UserBasic(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fmt.Sprintf("clo_storage_s3_user.%s", userName), "enabled", "false"),
+					resource.TestCheckResourceAttr(fmt.Sprintf("clo_storage_s3_user.%s", userName), "status", s3UserSuspended),
+				),
+			},
+			{
+				Config: testAccCloS3UserBasic(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fmt.Sprintf("clo_storage_s3_user.%s", userName), "enabled", "true"),
+					resource.TestCheckResourceAttr(fmt.Sprintf("clo_storage_s3_user.%s", userName), "status", s3UserActive),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloS3UserBasic(enabled bool) string {
 	return fmt.Sprintf(`resource "clo_storage_s3_user" "%s"{
  		project_id="%s"
  		canonical_name="%s"
  		max_buckets=2
  		user_quota_max_size=30
-	}`, userName, os.Getenv("CLO_API_PROJECT_ID"), userName)
+ 		enabled=%t
+	}`, userName, os.Getenv("CLO_API_PROJECT_ID"), userName, enabled)
+}
+
+func TestS3UserEnabled(t *testing.T) {
+	for status, want := range map[string]bool{
+		s3UserActive:     true,
+		s3UserResuming:   true,
+		s3UserSuspending: false,
+		s3UserSuspended:  false,
+	} {
+		if got := s3UserEnabled(status); got != want {
+			t.Errorf("s3UserEnabled(%q) = %t, want %t", status, got, want)
+		}
+	}
 }
 
 func testAccCheckS3UserExists(n string, s3UserItem *cloapi.S3User) resource.TestCheckFunc {
@@ -74,3 +121,165 @@ func testAccCheckS3UserDestroy(st *terraform.State) error {
 	}
 	return nil
 }
+
+func TestParseS3QuotaSize(t *testing.T) {
+	for in, want := range map[string]int{
+		"0":        0,
+		"30":       30,
+		"500GiB":   500,
+		"500 gib":  500,
+		"1.5TiB":   1536,
+		"2048MiB":  2,
+		"1PiB":     1 << 20,
+		" 10GiB  ": 10,
+	} {
+		got, err := parseS3QuotaSize(in)
+		if err != nil || got != want {
+			t.Errorf("parseS3QuotaSize(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "GiB", "-1GiB", "500GB", "512MiB", "0.1TiB", "lots"} {
+		if _, err := parseS3QuotaSize(in); err == nil {
+			t.Errorf("parseS3QuotaSize(%q) should fail", in)
+		}
+	}
+}
+
+func TestFormatS3QuotaSize(t *testing.T) {
+	for in, want := range map[int]string{
+		0:       "0",
+		30:      "30GiB",
+		1024:    "1TiB",
+		1536:    "1536GiB",
+		1 << 20: "1PiB",
+	} {
+		if got := formatS3QuotaSize(in); got != want {
+			t.Errorf("formatS3QuotaSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestS3UserQuotaDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "u-1",
+		Attributes: map[string]string{
+			"project_id":               "p-1",
+			"canonical_name":           "server",
+			"max_buckets":              "2",
+			"user_quota_max_size":      "1TiB",
+			"user_quota_max_objects":   "0",
+			"bucket_quota_max_size":    "0",
+			"bucket_quota_max_objects": "0",
+			"enabled":                  "true",
+		},
+	}
+	base := map[string]interface{}{
+		"project_id":     "p-1",
+		"canonical_name": "server",
+		"max_buckets":    2,
+	}
+	with := func(extra map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{}
+		for k, v := range base {
+			raw[k] = v
+		}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return raw
+	}
+
+	diff, err := resourceS3User().Diff(context.Background(), state, terraform.NewResourceConfigRaw(with(map[string]interface{}{
+		"user_quota_max_size": "1024",
+	})), nil)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("an equivalent size should not plan a change, got %v", diff.Attributes)
+	}
+
+	diff, err = resourceS3User().Diff(context.Background(), state, terraform.NewResourceConfigRaw(with(map[string]interface{}{
+		"user_quota_max_size": "0.5 TiB",
+	})), nil)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if a := diff.Attributes["user_quota_max_size"]; a == nil || a.New != "512GiB" {
+		t.Errorf("size should be planned normalized, got %v", a)
+	}
+
+	_, err = resourceS3User().Diff(context.Background(), state, terraform.NewResourceConfigRaw(with(map[string]interface{}{
+		"user_quota_max_size":   "500GiB",
+		"bucket_quota_max_size": "1TiB",
+	})), nil)
+	if err == nil {
+		t.Error("a bucket quota above the user quota should be rejected")
+	}
+
+	_, err = resourceS3User().Diff(context.Background(), state, terraform.NewResourceConfigRaw(with(map[string]interface{}{
+		"user_quota_max_size":      "1TiB",
+		"user_quota_max_objects":   1000,
+		"bucket_quota_max_objects": 5000,
+	})), nil)
+	if err == nil {
+		t.Error("a bucket object quota above the user object quota should be rejected")
+	}
+}
+
+func TestS3UserEnabledNeedsPreview(t *testing.T) {
+	cli, err := cloapi.New("token", "https://api.example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &providerMeta{v3: cli}
+	config := func(enabled bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id":          "p-1",
+			"canonical_name":      "server",
+			"max_buckets":         2,
+			"user_quota_max_size": "1TiB",
+			"enabled":             enabled,
+		})
+	}
+	state := &terraform.InstanceState{
+		ID: "u-1",
+		Attributes: map[string]string{
+			"project_id":               "p-1",
+			"canonical_name":           "server",
+			"max_buckets":              "2",
+			"user_quota_max_size":      "1TiB",
+			"user_quota_max_objects":   "0",
+			"bucket_quota_max_size":    "0",
+			"bucket_quota_max_objects": "0",
+			"enabled":                  "true",
+		},
+	}
+
+	if _, err := resourceS3User().Diff(context.Background(), nil, config(true), meta); err != nil {
+		t.Errorf("an enabled user needs no preview endpoints, got %v", err)
+	}
+	if _, err := resourceS3User().Diff(context.Background(), nil, config(false), meta); err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("creating a suspended user without preview endpoints must be refused at plan time, got %v", err)
+	}
+	if _, err := resourceS3User().Diff(context.Background(), state, config(false), meta); err == nil || !strings.Contains(err.Error(), "preview_endpoints") {
+		t.Errorf("suspending without preview endpoints must be refused at plan time, got %v", err)
+	}
+}
+
+func TestUpgradeS3UserStateV0(t *testing.T) {
+	state, err := upgradeS3UserStateV0(context.Background(), map[string]interface{}{
+		"id":                    "u-1",
+		"user_quota_max_size":   float64(1024),
+		"bucket_quota_max_size": float64(0),
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := state["user_quota_max_size"]; got != "1TiB" {
+		t.Errorf("user_quota_max_size = %v, want 1TiB", got)
+	}
+	if got := state["bucket_quota_max_size"]; got != "0" {
+		t.Errorf("bucket_quota_max_size = %v, want 0", got)
+	}
+}
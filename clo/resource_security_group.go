@@ -0,0 +1,307 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Security group rule enumerations, as the API reports them. Input is accepted
+// in any case.
+var (
+	securityGroupDirections = []string{"INGRESS", "EGRESS"}
+	securityGroupProtocols  = []string{"TCP", "UDP", "ICMP", "ANY"}
+)
+
+func resourceSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage a security group (a set of allow-rules applied to instances and load balancers " +
+			"with `clo_network_security_group_attach`). Rules may be declared inline with `rule` blocks or " +
+			"as separate `clo_network_security_group_rule` resources, but not both for the same group.",
+		ReadContext:   resourceSecurityGroupRead,
+		CreateContext: resourceSecurityGroupCreate,
+		UpdateContext: resourceSecurityGroupUpdate,
+		DeleteContext: resourceSecurityGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project where the security group should be created",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the security group",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Free-form description of the security group",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rule": {
+				Description: "Inline allow-rules. Rules are matched by content, so reordering them does not produce a diff; changing a rule replaces just that rule.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         securityGroupRuleHash,
+				Elem: &schema.Resource{
+					Schema: securityGroupRuleSchema(false),
+				},
+			},
+			"id": {
+				Description: "ID of the security group",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// securityGroupRuleSchema returns the rule fields shared by the inline `rule`
+// block and the standalone rule resource. Standalone rules are immutable, so
+// every field forces a new rule there.
+func securityGroupRuleSchema(forceNew bool) map[string]*schema.Schema {
+	portsNote := "ignored for `ICMP` and `ANY`"
+	if forceNew {
+		portsNote = "must be omitted for `ICMP` and `ANY`"
+	}
+	return map[string]*schema.Schema{
+		"direction": {
+			Description:      "Traffic direction the rule allows. One of `INGRESS`, `EGRESS`.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         forceNew,
+			ValidateFunc:     validation.StringInSlice(securityGroupDirections, true),
+			DiffSuppressFunc: suppressCaseDiff,
+		},
+		"protocol": {
+			Description:      "Protocol the rule allows. One of `TCP`, `UDP`, `ICMP`, `ANY`.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         forceNew,
+			ValidateFunc:     validation.StringInSlice(securityGroupProtocols, true),
+			DiffSuppressFunc: suppressCaseDiff,
+		},
+		"port_range_min": {
+			Description:  "First port of the allowed range. Omit for all ports; " + portsNote + ".",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     forceNew,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"port_range_max": {
+			Description:  "Last port of the allowed range. Defaults to `port_range_min`.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     forceNew,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"remote_cidr": {
+			Description:      "Source (ingress) or destination (egress) network in CIDR notation. Defaults to `0.0.0.0/0`.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         forceNew,
+			Default:          "0.0.0.0/0",
+			ValidateFunc:     validation.IsCIDR,
+			DiffSuppressFunc: suppressEquivalentCIDR,
+		},
+		"description": {
+			Description: "Free-form description of the rule",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    forceNew,
+		},
+		"id": {
+			Description: "ID of the rule",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id, err := cli.CreateSecurityGroup(ctx, d.Get("project_id").(string), cloapi.SecurityGroupParams{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		if _, err := cli.CreateSecurityGroupRule(ctx, id, expandSecurityGroupRule(r.(map[string]interface{}))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSecurityGroupRead(ctx, d, m)
+}
+
+func resourceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	g, err := cli.GetSecurityGroup(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":          g.ID,
+		"project_id":  g.Project,
+		"name":        g.Name,
+		"description": g.Description,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+
+	// Rules are only owned here when declared inline; otherwise they belong to
+	// clo_network_security_group_rule resources and must not show up as drift.
+	if _, ok := d.GetOk("rule"); ok {
+		if e := d.Set("rule", flattenSecurityGroupRules(g.Rules)); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChanges("name", "description") {
+		err := cli.UpdateSecurityGroup(ctx, d.Id(), cloapi.SecurityGroupParams{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("rule") {
+		o, n := d.GetChange("rule")
+		oldRules, newRules := o.(*schema.Set), n.(*schema.Set)
+		// Remove first so a changed rule does not collide with its old self on
+		// the API side.
+		for _, r := range oldRules.Difference(newRules).List() {
+			id := r.(map[string]interface{})["id"].(string)
+			if err := cli.DeleteSecurityGroupRule(ctx, d.Id(), id); err != nil && !cloapi.IsNotFound(err) {
+				return diag.FromErr(err)
+			}
+		}
+		for _, r := range newRules.Difference(oldRules).List() {
+			if _, err := cli.CreateSecurityGroupRule(ctx, d.Id(), expandSecurityGroupRule(r.(map[string]interface{}))); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return resourceSecurityGroupRead(ctx, d, m)
+}
+
+func resourceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeleteSecurityGroup(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Helpers
+
+func expandSecurityGroupRule(m map[string]interface{}) cloapi.SecurityGroupRuleCreateParams {
+	p := cloapi.SecurityGroupRuleCreateParams{
+		Direction:   strings.ToUpper(m["direction"].(string)),
+		Protocol:    strings.ToUpper(m["protocol"].(string)),
+		RemoteCIDR:  m["remote_cidr"].(string),
+		Description: m["description"].(string),
+	}
+	if p.Protocol == "TCP" || p.Protocol == "UDP" {
+		p.PortMin, p.PortMax = securityGroupRulePorts(m)
+	}
+	return p
+}
+
+func flattenSecurityGroupRules(rules []cloapi.SecurityGroupRule) *schema.Set {
+	out := schema.NewSet(securityGroupRuleHash, nil)
+	for _, r := range rules {
+		out.Add(map[string]interface{}{
+			"id":             r.ID,
+			"direction":      r.Direction,
+			"protocol":       r.Protocol,
+			"port_range_min": r.PortMin,
+			"port_range_max": r.PortMax,
+			"remote_cidr":    r.RemoteCIDR,
+			"description":    r.Description,
+		})
+	}
+	return out
+}
+
+// securityGroupRulePorts returns the effective port range of a rule: an unset
+// max means a single port.
+func securityGroupRulePorts(m map[string]interface{}) (int, int) {
+	lo, _ := m["port_range_min"].(int)
+	hi, _ := m["port_range_max"].(int)
+	if hi == 0 {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// securityGroupRuleHash identifies a rule by what it allows, normalised the
+// way the API stores it: enumerations upper-cased, the CIDR reduced to its
+// network address, and ports dropped for protocols that have none. The ID is
+// left out, so reordering rules, or the API echoing `10.0.0.5/24` back as
+// `10.0.0.0/24`, never produces a diff.
+func securityGroupRuleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	protocol := strings.ToUpper(m["protocol"].(string))
+	lo, hi := 0, 0
+	if protocol == "TCP" || protocol == "UDP" {
+		lo, hi = securityGroupRulePorts(m)
+	}
+	cidr, _ := m["remote_cidr"].(string)
+	desc, _ := m["description"].(string)
+	key := fmt.Sprintf("%s|%s|%d|%d|%s|%s",
+		strings.ToUpper(m["direction"].(string)), protocol, lo, hi, normalizeCIDR(cidr), desc)
+	return schema.HashString(key)
+}
+
+// normalizeCIDR returns the canonical form of a CIDR (network address plus
+// prefix length), or the input unchanged when it does not parse.
+func normalizeCIDR(cidr string) string {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr
+	}
+	return n.String()
+}
+
+func suppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func suppressEquivalentCIDR(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeCIDR(old) == normalizeCIDR(new)
+}
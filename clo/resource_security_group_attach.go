@@ -0,0 +1,136 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Entity kinds a security group can be applied to, matching entity_name on
+// clo_network_ip_attach.
+const (
+	serverEntity       = "server"
+	loadbalancerEntity = "loadbalancer"
+)
+
+func resourceSecurityGroupAttach() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Apply a security group to an entity, for example: a loadbalancer or a server. Import with `<security_group_id>/<entity_name>/<entity_id>`.",
+		ReadContext:   resourceSecurityGroupAttachRead,
+		CreateContext: resourceSecurityGroupAttachCreate,
+		DeleteContext: resourceSecurityGroupDetach,
+		Importer: &schema.ResourceImporter{
+			StateContext: importSecurityGroupAttach,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Description: "ID of the security group to apply",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"entity_id": {
+				Description: "ID of the entity the security group will be applied to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"entity_name": {
+				Description:  "Name of the entity. Should be `loadbalancer` or `server`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{loadbalancerEntity, serverEntity}, false),
+			},
+		},
+	}
+}
+
+func resourceSecurityGroupAttachCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	groupID, entityID, entity := d.Get("security_group_id").(string), d.Get("entity_id").(string), d.Get("entity_name").(string)
+
+	var err error
+	if entity == serverEntity {
+		err = cli.AttachServerSecurityGroup(ctx, entityID, groupID)
+	} else {
+		err = cli.AttachLoadBalancerSecurityGroup(ctx, entityID, groupID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strings.Join([]string{groupID, entity, entityID}, "/"))
+	return resourceSecurityGroupAttachRead(ctx, d, m)
+}
+
+func resourceSecurityGroupAttachRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	groupID, entityID := d.Get("security_group_id").(string), d.Get("entity_id").(string)
+
+	var groups []string
+	var err error
+	if d.Get("entity_name").(string) == serverEntity {
+		groups, err = cli.ListServerSecurityGroups(ctx, entityID)
+	} else {
+		groups, err = cli.ListLoadBalancerSecurityGroups(ctx, entityID)
+	}
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, g := range groups {
+		if g == groupID {
+			return nil
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceSecurityGroupDetach(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	groupID, entityID := d.Get("security_group_id").(string), d.Get("entity_id").(string)
+
+	var err error
+	if d.Get("entity_name").(string) == serverEntity {
+		err = cli.DetachServerSecurityGroup(ctx, entityID, groupID)
+	} else {
+		err = cli.DetachLoadBalancerSecurityGroup(ctx, entityID, groupID)
+	}
+	if err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func importSecurityGroupAttach(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" || (parts[1] != serverEntity && parts[1] != loadbalancerEntity) {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <security_group_id>/<server|loadbalancer>/<entity_id>", d.Id())
+	}
+	fields := map[string]interface{}{
+		"security_group_id": parts[0],
+		"entity_name":       parts[1],
+		"entity_id":         parts[2],
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return nil, e
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
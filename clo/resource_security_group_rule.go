@@ -0,0 +1,121 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecurityGroupRule() *schema.Resource {
+	s := securityGroupRuleSchema(true)
+	s["security_group_id"] = &schema.Schema{
+		Description: "ID of the security group the rule belongs to",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	return &schema.Resource{
+		Description:   "Manage a single allow-rule of a security group. Rules are immutable: any change replaces the rule. Import with `<security_group_id>/<rule_id>`.",
+		ReadContext:   resourceSecurityGroupRuleRead,
+		CreateContext: resourceSecurityGroupRuleCreate,
+		DeleteContext: resourceSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importSecurityGroupRule,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema:        s,
+		CustomizeDiff: validateSecurityGroupRulePorts,
+	}
+}
+
+func resourceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	p := expandSecurityGroupRule(map[string]interface{}{
+		"direction":      d.Get("direction"),
+		"protocol":       d.Get("protocol"),
+		"port_range_min": d.Get("port_range_min"),
+		"port_range_max": d.Get("port_range_max"),
+		"remote_cidr":    d.Get("remote_cidr"),
+		"description":    d.Get("description"),
+	})
+	id, err := cli.CreateSecurityGroupRule(ctx, d.Get("security_group_id").(string), p)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	return resourceSecurityGroupRuleRead(ctx, d, m)
+}
+
+func resourceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	r, err := cli.GetSecurityGroupRule(ctx, d.Get("security_group_id").(string), d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":                r.ID,
+		"security_group_id": r.SecurityGroup,
+		"direction":         r.Direction,
+		"protocol":          r.Protocol,
+		"port_range_min":    r.PortMin,
+		"port_range_max":    r.PortMax,
+		"remote_cidr":       r.RemoteCIDR,
+		"description":       r.Description,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	err := cli.DeleteSecurityGroupRule(ctx, d.Get("security_group_id").(string), d.Id())
+	if err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// validateSecurityGroupRulePorts rejects ports on protocols that have none. The
+// API drops them, so the configured ports would never match the state and the
+// rule would be replaced on every apply.
+func validateSecurityGroupRulePorts(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	protocol := strings.ToUpper(d.Get("protocol").(string))
+	if protocol == "TCP" || protocol == "UDP" || !d.NewValueKnown("protocol") {
+		return nil
+	}
+	for _, k := range []string{"port_range_min", "port_range_max"} {
+		if _, ok := d.GetOk(k); ok {
+			return fmt.Errorf("%s cannot be set for protocol %s, which has no ports", k, protocol)
+		}
+	}
+	return nil
+}
+
+func importSecurityGroupRule(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <security_group_id>/<rule_id>", d.Id())
+	}
+	if e := d.Set("security_group_id", parts[0]); e != nil {
+		return nil, e
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
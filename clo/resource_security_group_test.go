@@ -0,0 +1,175 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const securityGroupName = "sg_1"
+
+func TestAccCloSecurityGroup_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	sg := new(cloapi.SecurityGroup)
+	resName := fmt.Sprintf("clo_network_security_group.%s", securityGroupName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloSecurityGroupRules(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists(resName, sg),
+					resource.TestCheckResourceAttr(resName, "rule.#", "2"),
+				),
+			},
+			{
+				// Same rules in a different order and case must not plan a change.
+				Config:   testAccCloSecurityGroupRules(true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCloSecurityGroupRules(reordered bool) string {
+	ssh := `rule {
+			direction      = "INGRESS"
+			protocol       = "TCP"
+			port_range_min = 22
+			remote_cidr    = "10.0.0.0/8"
+		}`
+	web := `rule {
+			direction      = "INGRESS"
+			protocol       = "TCP"
+			port_range_min = 80
+		}`
+	if reordered {
+		ssh, web = `rule {
+			direction      = "ingress"
+			protocol       = "tcp"
+			port_range_min = 80
+		}`, `rule {
+			direction      = "ingress"
+			protocol       = "tcp"
+			port_range_min = 22
+			remote_cidr    = "10.0.0.0/8"
+		}`
+	}
+	return fmt.Sprintf(`resource "clo_network_security_group" "%s"{
+		project_id = "%s"
+		name       = "%s"
+		%s
+		%s
+	}`, securityGroupName, projectID, securityGroupName, ssh, web)
+}
+
+func testAccCheckSecurityGroupExists(n string, item *cloapi.SecurityGroup) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("security group ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		sg, e := cli.GetSecurityGroup(context.Background(), rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *sg
+		return nil
+	}
+}
+
+func testAccCheckSecurityGroupDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_network_security_group" {
+			continue
+		}
+		_, e := cli.GetSecurityGroup(context.Background(), rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("security group %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func TestSecurityGroupRuleHash(t *testing.T) {
+	rule := func(direction, protocol string, lo, hi int, cidr string) map[string]interface{} {
+		return map[string]interface{}{
+			"direction":      direction,
+			"protocol":       protocol,
+			"port_range_min": lo,
+			"port_range_max": hi,
+			"remote_cidr":    cidr,
+			"description":    "",
+			"id":             "",
+		}
+	}
+	base := rule("INGRESS", "TCP", 22, 22, "10.0.0.0/24")
+
+	same := []map[string]interface{}{
+		rule("ingress", "tcp", 22, 22, "10.0.0.0/24"),
+		rule("INGRESS", "TCP", 22, 0, "10.0.0.0/24"),
+		rule("INGRESS", "TCP", 22, 22, "10.0.0.5/24"),
+	}
+	for _, r := range same {
+		if securityGroupRuleHash(r) != securityGroupRuleHash(base) {
+			t.Errorf("%v should hash like %v", r, base)
+		}
+	}
+	withID := rule("INGRESS", "TCP", 22, 22, "10.0.0.0/24")
+	withID["id"] = "rule-1"
+	if securityGroupRuleHash(withID) != securityGroupRuleHash(base) {
+		t.Error("the computed ID must not affect the hash")
+	}
+
+	different := []map[string]interface{}{
+		rule("EGRESS", "TCP", 22, 22, "10.0.0.0/24"),
+		rule("INGRESS", "UDP", 22, 22, "10.0.0.0/24"),
+		rule("INGRESS", "TCP", 22, 23, "10.0.0.0/24"),
+		rule("INGRESS", "TCP", 22, 22, "10.0.1.0/24"),
+	}
+	for _, r := range different {
+		if securityGroupRuleHash(r) == securityGroupRuleHash(base) {
+			t.Errorf("%v should not hash like %v", r, base)
+		}
+	}
+
+	if securityGroupRuleHash(rule("INGRESS", "ICMP", 8, 0, "0.0.0.0/0")) != securityGroupRuleHash(rule("INGRESS", "icmp", 0, 0, "0.0.0.0/0")) {
+		t.Error("ports must be ignored for ICMP")
+	}
+}
+
+func TestSecurityGroupRulePortsDiff(t *testing.T) {
+	diff := func(raw map[string]interface{}) error {
+		raw["security_group_id"] = "sg-1"
+		raw["direction"] = "INGRESS"
+		_, err := resourceSecurityGroupRule().Diff(context.Background(), &terraform.InstanceState{}, terraform.NewResourceConfigRaw(raw), nil)
+		return err
+	}
+	if err := diff(map[string]interface{}{"protocol": "icmp", "port_range_min": 8}); err == nil {
+		t.Error("ports on an ICMP rule should be rejected")
+	}
+	if err := diff(map[string]interface{}{"protocol": "ANY", "port_range_max": 80}); err == nil {
+		t.Error("ports on an ANY rule should be rejected")
+	}
+	if err := diff(map[string]interface{}{"protocol": "ICMP"}); err != nil {
+		t.Errorf("an ICMP rule without ports should plan, got %v", err)
+	}
+	if err := diff(map[string]interface{}{"protocol": "tcp", "port_range_min": 22}); err != nil {
+		t.Errorf("a TCP rule with ports should plan, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package clo
+
+import (
+	"context"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSubnet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a subnet of a private network. `name`, `enable_dhcp` and `dns_nameservers` change in place; the address range and gateway force a new subnet.",
+		ReadContext:   resourceSubnetRead,
+		CreateContext: resourceSubnetCreate,
+		UpdateContext: resourceSubnetUpdate,
+		DeleteContext: resourceSubnetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Description: "ID of the private network the subnet belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the subnet",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"cidr": {
+				Description:  "Address range of the subnet in CIDR notation, e.g. `10.0.1.0/24`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"gateway_ip": {
+				Description:  "Gateway address inside `cidr`. Defaults to the first address of the range.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"enable_dhcp": {
+				Description: "Whether instances plugged into the subnet get their address over DHCP. Defaults to true.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"dns_nameservers": {
+				Description: "DNS servers handed out to instances in the subnet",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"id": {
+				Description: "ID of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	id, err := cli.CreateSubnet(ctx, d.Get("network_id").(string), cloapi.SubnetCreateParams{
+		Name:           d.Get("name").(string),
+		CIDR:           d.Get("cidr").(string),
+		GatewayIP:      optString(d, "gateway_ip"),
+		EnableDHCP:     d.Get("enable_dhcp").(bool),
+		DNSNameservers: expandStringList(d.Get("dns_nameservers").([]interface{})),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitSubnetState(ctx, id, cli, []string{creatingNetwork}, []string{activeNetwork}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSubnetRead(ctx, d, m)
+}
+
+func resourceSubnetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	s, err := cli.GetSubnet(ctx, d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":              s.ID,
+		"network_id":      s.Network,
+		"name":            s.Name,
+		"cidr":            s.CIDR,
+		"gateway_ip":      s.GatewayIP,
+		"enable_dhcp":     s.EnableDHCP,
+		"dns_nameservers": s.DNSNameservers,
+		"status":          s.Status,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if d.HasChanges("name", "enable_dhcp", "dns_nameservers") {
+		err := cli.UpdateSubnet(ctx, d.Id(), cloapi.SubnetUpdateParams{
+			Name:           d.Get("name").(string),
+			EnableDHCP:     d.Get("enable_dhcp").(bool),
+			DNSNameservers: expandStringList(d.Get("dns_nameservers").([]interface{})),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitSubnetState(ctx, d.Id(), cli, []string{updatingNetwork}, []string{activeNetwork}, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSubnetRead(ctx, d, m)
+}
+
+func resourceSubnetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	if err := cli.DeleteSubnet(ctx, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if err := waitSubnetDeleted(ctx, d.Id(), cli, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Waiters
+
+func waitSubnetState(ctx context.Context, id string, cli *cloapi.Client, pending, target []string, timeout time.Duration) error {
+	return waitForState(ctx, timeout, pending, target, func() (interface{}, string, error) {
+		s, err := cli.GetSubnet(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return s, s.Status, nil
+	})
+}
+
+func waitSubnetDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitForState(ctx, timeout, []string{activeNetwork, deletingNetwork}, []string{deletedNetwork}, func() (interface{}, string, error) {
+		s, err := cli.GetSubnet(ctx, id)
+		if cloapi.IsNotFound(err) {
+			return struct{}{}, deletedNetwork, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return s, s.Status, nil
+	})
+}
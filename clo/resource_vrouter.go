@@ -18,6 +18,7 @@ const (
 	startingVrouter = "STARTING"
 	stoppingVrouter = "STOPPING"
 	stoppedVrouter  = "STOPPED"
+	updatingVrouter = "UPDATING"
 	deletingVrouter = "DELETING"
 	deletedVrouter  = "DELETED"
 	errorVrouter    = "ERROR"
@@ -68,7 +69,7 @@ func resourceVrouter() *schema.Resource {
 				Computed:    true,
 			},
 			"status": {
-				Description: "Lifecycle status of the virtual router (CREATING/ACTIVE/STARTING/STOPPING/STOPPED/DELETING/DELETED/ERROR)",
+				Description: "Lifecycle status of the virtual router (CREATING/ACTIVE/STARTING/STOPPING/STOPPED/UPDATING/DELETING/DELETED/ERROR)",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
@@ -206,8 +207,15 @@ func waitVrouterEnabled(ctx context.Context, id string, cli *cloapi.Client, enab
 	return waitVrouterState(ctx, id, cli, []string{activeVrouter, stoppingVrouter}, []string{stoppedVrouter}, timeout)
 }
 
+// waitVrouterSettled waits for a configuration change (route or NAT rule added
+// or removed) to leave the transient UPDATING state and settle back to whatever
+// power state the router held.
+func waitVrouterSettled(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
+	return waitVrouterState(ctx, id, cli, []string{updatingVrouter}, []string{activeVrouter, stoppedVrouter}, timeout)
+}
+
 func waitVrouterDeleted(ctx context.Context, id string, cli *cloapi.Client, timeout time.Duration) error {
-	pending := []string{creatingVrouter, activeVrouter, startingVrouter, stoppingVrouter, stoppedVrouter, deletingVrouter}
+	pending := []string{creatingVrouter, activeVrouter, startingVrouter, stoppingVrouter, stoppedVrouter, updatingVrouter, deletingVrouter}
 	return waitForState(ctx, timeout, pending, []string{deletedVrouter}, func() (interface{}, string, error) {
 		v, err := cli.GetVrouter(ctx, id)
 		if cloapi.IsNotFound(err) {
@@ -0,0 +1,135 @@
+package clo
+
+import (
+	"context"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVrouterNatRule() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a port-forwarding (NAT) rule on a virtual router: traffic arriving on `external_port` of the router's gateway address is forwarded to `internal_port` on `internal_address`. Import with `<vrouter_id>/<rule_id>`.",
+		ReadContext:   resourceVrouterNatRuleRead,
+		CreateContext: resourceVrouterNatRuleCreate,
+		DeleteContext: resourceVrouterNatRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importVrouterChild,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vrouter_id": {
+				Description: "ID of the virtual router the rule belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"protocol": {
+				Description:  "Protocol to forward. One of `TCP`, `UDP`. Defaults to `TCP`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "TCP",
+				ValidateFunc: validation.StringInSlice([]string{"TCP", "UDP"}, false),
+			},
+			"external_port": {
+				Description:  "Port on the router's gateway address",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"internal_address": {
+				Description:  "Private address the traffic is forwarded to",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"internal_port": {
+				Description:  "Port on `internal_address` the traffic is forwarded to",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"id": {
+				Description: "ID of the rule",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the rule",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVrouterNatRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	vrouterID := d.Get("vrouter_id").(string)
+	id, err := cli.CreateVrouterNatRule(ctx, vrouterID, cloapi.VrouterNatRuleCreateParams{
+		Protocol:        d.Get("protocol").(string),
+		ExternalPort:    d.Get("external_port").(int),
+		InternalAddress: d.Get("internal_address").(string),
+		InternalPort:    d.Get("internal_port").(int),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitVrouterSettled(ctx, vrouterID, cli, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceVrouterNatRuleRead(ctx, d, m)
+}
+
+func resourceVrouterNatRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	r, err := cli.GetVrouterNatRule(ctx, d.Get("vrouter_id").(string), d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":               r.ID,
+		"vrouter_id":       r.Vrouter,
+		"protocol":         r.Protocol,
+		"external_port":    r.ExternalPort,
+		"internal_address": r.InternalAddress,
+		"internal_port":    r.InternalPort,
+		"status":           r.Status,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceVrouterNatRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	vrouterID := d.Get("vrouter_id").(string)
+	if err := cli.DeleteVrouterNatRule(ctx, vrouterID, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	// The router may be gone already, which leaves nothing to settle.
+	if err := waitVrouterSettled(ctx, vrouterID, cli, d.Timeout(schema.TimeoutDelete)); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const vrouterNatRuleName = "nat_1"
+
+func TestAccCloVrouterNatRule_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	rule := new(cloapi.VrouterNatRule)
+	resName := fmt.Sprintf("clo_network_vrouter_nat_rule.%s", vrouterNatRuleName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVrouterNatRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloVrouterNatRuleBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVrouterNatRuleExists(resName, rule),
+					resource.TestCheckResourceAttr(resName, "protocol", "TCP"),
+					resource.TestCheckResourceAttr(resName, "external_port", "2222"),
+					resource.TestCheckResourceAttr(resName, "internal_port", "22"),
+					resource.TestCheckResourceAttrSet(resName, "status"),
+				),
+			},
+			{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resName]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["vrouter_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCloVrouterNatRuleBasic() string {
+	return fmt.Sprintf(`%s
+	resource "clo_network_vrouter_nat_rule" "%s"{
+			vrouter_id       = clo_network_vrouter.%s.id
+			external_port    = 2222
+			internal_address = "10.0.0.7"
+			internal_port    = 22
+	}`, testAccCloVrouterBasic(), vrouterNatRuleName, vrouterName)
+}
+
+func testAccCheckVrouterNatRuleExists(n string, item *cloapi.VrouterNatRule) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("NAT rule ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		r, e := cli.GetVrouterNatRule(context.Background(), rs.Primary.Attributes["vrouter_id"], rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *r
+		return nil
+	}
+}
+
+func testAccCheckVrouterNatRuleDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_network_vrouter_nat_rule" {
+			continue
+		}
+		_, e := cli.GetVrouterNatRule(context.Background(), rs.Primary.Attributes["vrouter_id"], rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("NAT rule %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
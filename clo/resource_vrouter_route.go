@@ -0,0 +1,132 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVrouterRoute() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a static route on a virtual router. Import with `<vrouter_id>/<route_id>`.",
+		ReadContext:   resourceVrouterRouteRead,
+		CreateContext: resourceVrouterRouteCreate,
+		DeleteContext: resourceVrouterRouteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importVrouterChild,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vrouter_id": {
+				Description: "ID of the virtual router the route belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"destination": {
+				Description:  "Destination network in CIDR notation, e.g. `10.10.0.0/24`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"nexthop": {
+				Description:  "Address the traffic for `destination` is forwarded to",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"id": {
+				Description: "ID of the route",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Lifecycle status of the route",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVrouterRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	vrouterID := d.Get("vrouter_id").(string)
+	id, err := cli.CreateVrouterRoute(ctx, vrouterID, cloapi.VrouterRouteCreateParams{
+		Destination: d.Get("destination").(string),
+		Nexthop:     d.Get("nexthop").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := waitVrouterSettled(ctx, vrouterID, cli, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceVrouterRouteRead(ctx, d, m)
+}
+
+func resourceVrouterRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	r, err := cli.GetVrouterRoute(ctx, d.Get("vrouter_id").(string), d.Id())
+	if cloapi.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := map[string]interface{}{
+		"id":          r.ID,
+		"vrouter_id":  r.Vrouter,
+		"destination": r.Destination,
+		"nexthop":     r.Nexthop,
+		"status":      r.Status,
+	}
+	for k, val := range fields {
+		if e := d.Set(k, val); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+	return nil
+}
+
+func resourceVrouterRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cli := m.(*providerMeta).v3
+	vrouterID := d.Get("vrouter_id").(string)
+	if err := cli.DeleteVrouterRoute(ctx, vrouterID, d.Id()); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	// The router may be gone already, which leaves nothing to settle.
+	if err := waitVrouterSettled(ctx, vrouterID, cli, d.Timeout(schema.TimeoutDelete)); err != nil && !cloapi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// importVrouterChild imports a resource that lives under a virtual router
+// (route, NAT rule) from a `<vrouter_id>/<id>` import ID.
+func importVrouterChild(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <vrouter_id>/<id>", d.Id())
+	}
+	if e := d.Set("vrouter_id", parts[0]); e != nil {
+		return nil, e
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
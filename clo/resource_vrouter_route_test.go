@@ -0,0 +1,90 @@
+package clo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/cloapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const vrouterRouteName = "route_1"
+
+func TestAccCloVrouterRoute_basic(t *testing.T) {
+	skipIfNotPreview(t)
+	route := new(cloapi.VrouterRoute)
+	resName := fmt.Sprintf("clo_network_vrouter_route.%s", vrouterRouteName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccCloPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVrouterRouteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloVrouterRouteBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVrouterRouteExists(resName, route),
+					resource.TestCheckResourceAttr(resName, "destination", "10.20.0.0/24"),
+					resource.TestCheckResourceAttr(resName, "nexthop", "10.0.0.5"),
+					resource.TestCheckResourceAttrSet(resName, "status"),
+				),
+			},
+			{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resName]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["vrouter_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCloVrouterRouteBasic() string {
+	return fmt.Sprintf(`%s
+	resource "clo_network_vrouter_route" "%s"{
+			vrouter_id  = clo_network_vrouter.%s.id
+			destination = "10.20.0.0/24"
+			nexthop     = "10.0.0.5"
+	}`, testAccCloVrouterBasic(), vrouterRouteName, vrouterName)
+}
+
+func testAccCheckVrouterRouteExists(n string, item *cloapi.VrouterRoute) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("route ID is not set")
+		}
+		cli := testAccProvider.Meta().(*providerMeta).v3
+		r, e := cli.GetVrouterRoute(context.Background(), rs.Primary.Attributes["vrouter_id"], rs.Primary.ID)
+		if e != nil {
+			return e
+		}
+		*item = *r
+		return nil
+	}
+}
+
+func testAccCheckVrouterRouteDestroy(st *terraform.State) error {
+	cli := testAccProvider.Meta().(*providerMeta).v3
+	for _, rs := range st.RootModule().Resources {
+		if rs.Type != "clo_network_vrouter_route" {
+			continue
+		}
+		_, e := cli.GetVrouterRoute(context.Background(), rs.Primary.Attributes["vrouter_id"], rs.Primary.ID)
+		if cloapi.IsNotFound(e) {
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		return fmt.Errorf("route %s still exists", rs.Primary.ID)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package clo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// withS3Connection adds the arguments the storage resources use to reach the
+// CLO S3 endpoint with one user's keys, such as those of a
+// clo_storage_s3_user_keys resource.
+func withS3Connection(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	fields["endpoint"] = &schema.Schema{
+		Description:  "URL of the S3 endpoint. Defaults to the provider's `s3_endpoint`. Changing it only changes where the resource is reached.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+	}
+	fields["region"] = &schema.Schema{
+		Description: "Region to sign requests for. Defaults to `" + s3api.DefaultRegion + "`, which S3-compatible services without regions accept.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     s3api.DefaultRegion,
+	}
+	fields["access_key"] = &schema.Schema{
+		Description: "Access key of the S3 user that owns the bucket",
+		Type:        schema.TypeString,
+		Required:    true,
+		Sensitive:   true,
+	}
+	fields["secret_key"] = &schema.Schema{
+		Description: "Secret key of the S3 user that owns the bucket",
+		Type:        schema.TypeString,
+		Required:    true,
+		Sensitive:   true,
+	}
+	return fields
+}
+
+// s3ClientFor builds a client from the withS3Connection arguments.
+func s3ClientFor(d *schema.ResourceData, m interface{}) (*s3api.Client, error) {
+	endpoint := s3EndpointFor(d, m)
+	if endpoint == "" {
+		return nil, errors.New("no S3 endpoint: set endpoint on the resource or s3_endpoint on the provider")
+	}
+	return s3api.New(endpoint, d.Get("region").(string), d.Get("access_key").(string), d.Get("secret_key").(string))
+}
+
+// planS3Endpoint drops an endpoint change that still reaches the same
+// service, such as setting endpoint to the provider's s3_endpoint or adding a
+// trailing slash; any other change is an in-place update, since the objects
+// stay where they are. endpoint is Computed only so that the change can be
+// dropped, so an endpoint removed from the configuration is planned back to
+// the provider's here.
+func planS3Endpoint(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("endpoint") {
+		return nil
+	}
+	o, n := d.GetChange("endpoint")
+	old, planned := o.(string), n.(string)
+	if cfg := d.GetRawConfig(); !cfg.IsNull() && cfg.GetAttr("endpoint").IsNull() {
+		planned = ""
+	}
+	if old == planned {
+		return nil
+	}
+	if effectiveS3Endpoint(old, m) == effectiveS3Endpoint(planned, m) {
+		if d.HasChange("endpoint") {
+			return d.Clear("endpoint")
+		}
+		return nil
+	}
+	return d.SetNew("endpoint", planned)
+}
+
+// effectiveS3Endpoint is the endpoint a resource configured with endpoint
+// reaches, without trailing slashes.
+func effectiveS3Endpoint(endpoint string, m interface{}) string {
+	if endpoint == "" {
+		endpoint = m.(*providerMeta).s3Endpoint
+	}
+	return strings.TrimRight(endpoint, "/")
+}
+
+func s3EndpointFor(d *schema.ResourceData, m interface{}) string {
+	if e := d.Get("endpoint").(string); e != "" {
+		return e
+	}
+	return m.(*providerMeta).s3Endpoint
+}
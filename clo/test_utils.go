@@ -3,6 +3,7 @@ package clo
 import (
 	"context"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -12,7 +13,14 @@ import (
 )
 
 func getTestClient() (*cloapi.Client, error) {
-	return cloapi.New(os.Getenv("CLO_API_AUTH_TOKEN"), os.Getenv("CLO_API_AUTH_URL"))
+	return cloapi.New(os.Getenv("CLO_API_AUTH_TOKEN"), os.Getenv("CLO_API_AUTH_URL"), testAccPreview())
+}
+
+// testAccPreview reports whether CLO_PREVIEW_ENDPOINTS enables the preview
+// endpoints, and with them the pending resources, for the acceptance tests.
+func testAccPreview() bool {
+	preview, _ := strconv.ParseBool(os.Getenv("CLO_PREVIEW_ENDPOINTS"))
+	return preview
 }
 
 func getTestProject() string {
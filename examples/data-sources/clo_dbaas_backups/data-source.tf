@@ -1,3 +1,11 @@
 data "clo_dbaas_backups" "all" {
   project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
-}
\ No newline at end of file
+}
+
+# FULL backups of one cluster taken within the last week.
+data "clo_dbaas_backups" "recent_full" {
+  project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  cluster_id = clo_dbaas_cluster.cluster_1.id
+  type       = "FULL"
+  newer_than = "7d"
+}
@@ -0,0 +1,24 @@
+# Connection details for an application database, as its admin user, over the
+# cluster's internal address.
+data "clo_dbaas_connection" "app" {
+  cluster_id  = clo_dbaas_cluster.cluster_1.id
+  database_id = clo_dbaas_database.app.id
+  password    = clo_dbaas_database.app.admin_password
+  network     = "internal"
+  parameters = {
+    sslmode = "require"
+  }
+}
+
+# The same database as another user of the cluster.
+data "clo_dbaas_connection" "reporting" {
+  cluster_id  = clo_dbaas_cluster.cluster_1.id
+  database_id = clo_dbaas_database.app.id
+  username    = "reporting"
+  password    = var.reporting_password
+}
+
+output "app_database_url" {
+  value     = data.clo_dbaas_connection.app.uri
+  sensitive = true
+}
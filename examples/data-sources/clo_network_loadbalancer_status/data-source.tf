@@ -0,0 +1,12 @@
+# Fail `terraform apply -refresh-only` (and thus the pipeline) unless every
+# backend behind the balancer passes its health check.
+data "clo_network_loadbalancer_status" "web" {
+  loadbalancer_id = "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+
+  lifecycle {
+    postcondition {
+      condition     = self.healthy
+      error_message = "Unhealthy backends: ${join(", ", [for r in self.result : "${r.address}:${r.internal_protocol_port} ${r.operating_status} (${r.last_check_detail})" if r.operating_status != "ONLINE"])}"
+    }
+  }
+}
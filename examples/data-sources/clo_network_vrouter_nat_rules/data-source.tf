@@ -0,0 +1,3 @@
+data "clo_network_vrouter_nat_rules" "router_1" {
+  vrouter_id = "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+}
@@ -0,0 +1,16 @@
+data "clo_storage_s3_usage" "usage" {
+  user_id    = clo_storage_s3_user.s3_user.id
+  access_key = clo_storage_s3_user_keys.s3_userkeys.access_key
+  secret_key = clo_storage_s3_user_keys.s3_userkeys.secret_key
+  # Lists every object of the user; without it only the quotas are reported.
+  count_objects = true
+}
+
+# Names of the buckets at 80% or more of the bucket quota, e.g. to feed alerts.
+output "buckets_near_quota" {
+  value = [for b in data.clo_storage_s3_usage.usage.buckets : b.name if b.over_threshold]
+}
+
+output "user_size_headroom_bytes" {
+  value = data.clo_storage_s3_usage.usage.size_headroom
+}
@@ -16,4 +16,25 @@ resource "clo_compute_instance" "myserv" {
     external        = true
     ddos_protection = false
   }
-}
\ No newline at end of file
+}
+
+# An application server with no public address, plugged into a private
+# network. Adding or removing a network_interface block attaches or detaches
+# just that NIC. NICs need the provider's preview_endpoints.
+resource "clo_compute_instance" "app" {
+  project_id   = "e9ff0f7-0b8c-4ec5-a0a4-e30ce0db287"
+  name         = "app_1"
+  flavor_ram   = 4
+  flavor_vcpus = 2
+  image_id     = "2d6270-c4b6-4d2c-b238-8fa58f35634d"
+  block_device {
+    bootable     = true
+    storage_type = "volume"
+    size         = 40
+  }
+  network_interface {
+    network_id = "7b1c9e4a-3f0d-4c2e-9a8b-5d6e7f809a1b"
+    subnet_id  = "c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f"
+    fixed_ip   = "10.0.1.10"
+  }
+}
@@ -0,0 +1,30 @@
+data "clo_dbaas_backups" "app_db" {
+  project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+}
+
+locals {
+  full_backup = [for b in data.clo_dbaas_backups.app_db.result : b if b.cluster_id == clo_dbaas_cluster.cluster_1.id && b.type == "FULL" && b.status == "AVAILABLE"][0]
+}
+
+# Keep a local copy of the backup; a new backup ID replaces the file.
+resource "clo_dbaas_backup_export" "local" {
+  backup_id  = local.full_backup.id
+  local_path = "${path.module}/backups/app-db.tar"
+}
+
+# Keep an offsite copy in another S3-compatible storage.
+resource "clo_dbaas_backup_export" "offsite" {
+  backup_id = local.full_backup.id
+
+  s3 {
+    endpoint   = "https://s3.example.com"
+    bucket     = "db-backups"
+    key        = "app-db/${local.full_backup.id}.tar"
+    access_key = var.offsite_access_key
+    secret_key = var.offsite_secret_key
+  }
+}
+
+output "app_db_backup_sha256" {
+  value = clo_dbaas_backup_export.offsite.sha256
+}
@@ -3,12 +3,16 @@ data "clo_dbaas_datastores" "all" {
   project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
 }
 
-# A minimal managed-database cluster. An address is allocated automatically.
+# A managed-database cluster with one read replica and nightly backups at 03:00
+# UTC, kept for 14 days. An address is allocated automatically.
 resource "clo_dbaas_cluster" "cluster_1" {
-  project_id   = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
-  name         = "app-db"
-  datastore_id = data.clo_dbaas_datastores.all.result[0].id
-  storage_size = 20
+  project_id       = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name             = "app-db"
+  datastore_id     = data.clo_dbaas_datastores.all.result[0].id
+  storage_size     = 20
+  replicas         = 1
+  backup_hour      = 3
+  backup_retention = 14
 
   flavor {
     vcpus = 2
@@ -33,4 +37,22 @@ resource "clo_dbaas_cluster" "cluster_2" {
   address {
     id = "b0298e0c-057c-4298-8505-5e57f5a60f8c"
   }
-}
\ No newline at end of file
+}
+# A copy of cluster_1 as it was at a given moment, e.g. just before a bad
+# migration. The timestamp must fall inside cluster_1's backup window.
+resource "clo_dbaas_cluster" "cluster_1_restored" {
+  project_id   = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name         = "app-db-restored"
+  datastore_id = clo_dbaas_cluster.cluster_1.datastore_id
+  storage_size = 20
+
+  flavor {
+    vcpus = 2
+    ram   = 4
+  }
+
+  restore_point_in_time {
+    source_cluster_id = clo_dbaas_cluster.cluster_1.id
+    timestamp         = "2026-10-01T12:30:00Z"
+  }
+}
@@ -0,0 +1,3 @@
+# Parameters are imported by the cluster ID. After import only the parameters
+# present in the configuration are managed.
+terraform import clo_dbaas_cluster_parameters.app_db 3f2504e0-4f89-41d3-9a0c-0305e82c3301
@@ -0,0 +1,13 @@
+# Tune a PostgreSQL cluster. Only the listed parameters are managed; if the
+# engine rejects the new values, they are restored from the last stable
+# configuration and the apply fails.
+resource "clo_dbaas_cluster_parameters" "app_db" {
+  cluster_id        = clo_dbaas_cluster.cluster_1.id
+  rollback_on_error = true
+
+  parameters = {
+    max_connections            = "200"
+    shared_buffers             = "1GB"
+    log_min_duration_statement = "500"
+  }
+}
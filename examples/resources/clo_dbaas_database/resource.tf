@@ -5,4 +5,24 @@ resource "clo_dbaas_database" "app" {
   name           = "app"
   admin_username = "app_admin"
   admin_password = "change-me-please"
-}
\ No newline at end of file
+}
+
+# A database with an explicit encoding and collation. The admin password comes
+# from a generator; bumping admin_password_version produces a new password and
+# applies it without recreating the database.
+resource "random_password" "reports_admin" {
+  length = 24
+  keepers = {
+    version = 3
+  }
+}
+
+resource "clo_dbaas_database" "reports" {
+  cluster_id             = clo_dbaas_cluster.cluster_1.id
+  name                   = "reports"
+  charset                = "UTF8"
+  collation              = "en_US.UTF-8"
+  admin_username         = "reports_admin"
+  admin_password         = random_password.reports_admin.result
+  admin_password_version = random_password.reports_admin.keepers.version
+}
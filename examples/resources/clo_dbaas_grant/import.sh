@@ -0,0 +1,2 @@
+# Grants are imported by <user_id>/<database_id>.
+terraform import clo_dbaas_grant.reporting_app 7c9e6679-7425-40de-944b-e07fc1f90ae7/16fd2706-8baf-433b-82eb-8c7fada847da
@@ -0,0 +1,6 @@
+# Read-only access to the app database for the reporting login.
+resource "clo_dbaas_grant" "reporting_app" {
+  user_id     = clo_dbaas_user.reporting.id
+  database_id = clo_dbaas_database.app.id
+  privileges  = ["SELECT"]
+}
@@ -0,0 +1,20 @@
+data "clo_dbaas_nodes" "app_db" {
+  cluster_id = clo_dbaas_cluster.cluster_1.id
+}
+
+# Promote the cluster's replica to primary, e.g. ahead of maintenance on the
+# current primary. Bump the trigger to switch over again. After the switchover
+# the old primary is the replica, so node_id is ignored once chosen to keep the
+# lookup from triggering another one.
+resource "clo_dbaas_switchover" "app_db" {
+  cluster_id = clo_dbaas_cluster.cluster_1.id
+  node_id    = [for n in data.clo_dbaas_nodes.app_db.result : n.id if n.role == "REPLICA"][0]
+
+  triggers = {
+    maintenance = "2026-10-20"
+  }
+
+  lifecycle {
+    ignore_changes = [node_id]
+  }
+}
@@ -0,0 +1,3 @@
+# Users are imported by their ID. The password is write-only, so set it in the
+# configuration afterwards; the next apply rotates it to the configured value.
+terraform import clo_dbaas_user.reporting 7c9e6679-7425-40de-944b-e07fc1f90ae7
@@ -0,0 +1,8 @@
+# A least-privilege login for the reporting service, allowed only from the
+# private network. Grant it access with clo_dbaas_grant.
+resource "clo_dbaas_user" "reporting" {
+  cluster_id = clo_dbaas_cluster.cluster_1.id
+  name       = "reporting"
+  password   = "change-me-please"
+  host       = "10.0.0.%"
+}
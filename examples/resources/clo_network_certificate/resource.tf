@@ -0,0 +1,27 @@
+resource "clo_network_certificate" "site" {
+  project_id  = "bb70fe1b-6a38-4d67-a37d-f0c5e1f1e5a0"
+  name        = "www.example.com"
+  certificate = file("${path.module}/tls/fullchain.pem")
+  private_key = file("${path.module}/tls/privkey.pem")
+}
+
+# Terminate TLS on the balancer and forward plain HTTP to the backend.
+resource "clo_network_loadbalancer_rule" "https" {
+  loadbalancer_id        = clo_network_loadbalancer.lb_1.id
+  address_id             = "c47676ad-9124-4a56-8982-196bfa997187"
+  protocol               = "HTTPS"
+  certificate_id         = clo_network_certificate.site.id
+  x_forwarded_for        = true
+  external_protocol_port = 443
+  internal_protocol_port = 8080
+}
+
+# Send plain-HTTP visitors to the HTTPS listener.
+resource "clo_network_loadbalancer_rule" "redirect" {
+  loadbalancer_id        = clo_network_loadbalancer.lb_1.id
+  address_id             = "c47676ad-9124-4a56-8982-196bfa997187"
+  protocol               = "HTTP"
+  redirect_http_to_https = true
+  external_protocol_port = 80
+  internal_protocol_port = 8080
+}
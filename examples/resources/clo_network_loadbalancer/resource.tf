@@ -38,4 +38,30 @@ resource "clo_network_loadbalancer" "lb_2" {
     url_path       = "/health"
     expected_codes = "200"
   }
-}
\ No newline at end of file
+}
+
+# A small balancer declared together with its listener rules. Rules added or
+# removed in the panel show up as a diff on the next plan.
+resource "clo_network_loadbalancer" "lb_3" {
+  project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name       = "inline-lb"
+
+  healthmonitor {
+    type        = "TCP"
+    delay       = 80
+    timeout     = 15
+    max_retries = 3
+  }
+
+  # address_id is a backend server's internal (FIXED) address.
+  rule {
+    address_id             = "c47676ad-9124-4a56-8982-196bfa997187"
+    external_protocol_port = 80
+    internal_protocol_port = 8080
+  }
+  rule {
+    address_id             = "5a1f1d07-3c3b-4b39-9f5e-0d7a4ac3c2a1"
+    external_protocol_port = 80
+    internal_protocol_port = 8080
+  }
+}
@@ -0,0 +1 @@
+terraform import clo_network_loadbalancer_pool.web 6f1c2a3b-4d5e-4f60-8a7b-9c0d1e2f3a4b
@@ -0,0 +1,23 @@
+# A pool spreads port 443 across two backends. The second one is being
+# drained: it finishes its open connections but receives no new ones.
+resource "clo_network_loadbalancer_pool" "web" {
+  loadbalancer_id        = clo_network_loadbalancer.lb_1.id
+  name                   = "web"
+  external_protocol_port = 443
+
+  member {
+    address_id = "c47676ad-9124-4a56-8982-196bfa997187"
+    port       = 8443
+    weight     = 3
+  }
+
+  member {
+    address_id  = "5e0c1b9a-2f3d-4c7e-9a8b-1d2e3f4a5b6c"
+    port        = 8443
+    admin_state = "DRAINING"
+  }
+}
+
+output "web_member_health" {
+  value = { for m in clo_network_loadbalancer_pool.web.member : m.address_id => m.operating_status }
+}
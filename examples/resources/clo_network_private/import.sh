@@ -0,0 +1 @@
+terraform import clo_network_private.backend 3f2504e0-4f89-41d3-9a0c-0305e82c3301
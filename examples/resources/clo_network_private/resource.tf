@@ -0,0 +1,4 @@
+resource "clo_network_private" "backend" {
+  project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name       = "backend"
+}
@@ -0,0 +1 @@
+terraform import clo_network_security_group.web 0c2d5b8e-8f77-4d8a-9a53-2f1f6a8e0d11
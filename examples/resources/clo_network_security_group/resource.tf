@@ -0,0 +1,29 @@
+# Web tier: HTTP(S) from anywhere, SSH only from the office. Rules are matched
+# by content, so their order here does not matter.
+resource "clo_network_security_group" "web" {
+  project_id  = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name        = "web"
+  description = "Public web servers"
+
+  rule {
+    direction      = "INGRESS"
+    protocol       = "TCP"
+    port_range_min = 80
+  }
+  rule {
+    direction      = "INGRESS"
+    protocol       = "TCP"
+    port_range_min = 443
+  }
+  rule {
+    direction      = "INGRESS"
+    protocol       = "TCP"
+    port_range_min = 22
+    remote_cidr    = "203.0.113.0/24"
+    description    = "office SSH"
+  }
+  rule {
+    direction = "EGRESS"
+    protocol  = "ANY"
+  }
+}
@@ -0,0 +1,3 @@
+# Attachments are imported by the security group ID, the entity name and the
+# entity ID separated by slashes.
+terraform import clo_network_security_group_attach.web_server 0c2d5b8e-8f77-4d8a-9a53-2f1f6a8e0d11/server/2b5f8d4c-1f0e-4a3b-9c7d-6e5f4a3b2c1d
@@ -0,0 +1,11 @@
+resource "clo_network_security_group_attach" "web_server" {
+  security_group_id = clo_network_security_group.web.id
+  entity_name       = "server"
+  entity_id         = clo_compute_instance.myserv.id
+}
+
+resource "clo_network_security_group_attach" "web_lb" {
+  security_group_id = clo_network_security_group.web.id
+  entity_name       = "loadbalancer"
+  entity_id         = clo_network_loadbalancer.lb_1.id
+}
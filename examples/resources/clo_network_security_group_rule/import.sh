@@ -0,0 +1,2 @@
+# Rules are imported by the security group ID and the rule ID separated by a slash.
+terraform import clo_network_security_group_rule.postgres_from_app 0c2d5b8e-8f77-4d8a-9a53-2f1f6a8e0d11/7d444840-9dc0-11d1-b245-5ffdce74fad2
@@ -0,0 +1,14 @@
+# Standalone rules suit groups whose rules are owned by several modules. Do not
+# mix them with inline rule blocks on the same group.
+resource "clo_network_security_group" "db" {
+  project_id = "1e8ff0f7-0b8c-4ec5-a0a4-e30cea0db287"
+  name       = "db"
+}
+
+resource "clo_network_security_group_rule" "postgres_from_app" {
+  security_group_id = clo_network_security_group.db.id
+  direction         = "INGRESS"
+  protocol          = "TCP"
+  port_range_min    = 5432
+  remote_cidr       = "10.0.1.0/24"
+}
@@ -0,0 +1 @@
+terraform import clo_network_subnet.backend 9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d
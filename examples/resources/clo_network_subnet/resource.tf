@@ -0,0 +1,8 @@
+resource "clo_network_subnet" "backend" {
+  network_id      = clo_network_private.backend.id
+  name            = "backend-a"
+  cidr            = "10.0.1.0/24"
+  gateway_ip      = "10.0.1.1"
+  enable_dhcp     = true
+  dns_nameservers = ["10.0.1.2", "8.8.8.8"]
+}
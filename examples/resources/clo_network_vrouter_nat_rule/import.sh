@@ -0,0 +1,2 @@
+# NAT rules are imported by the router ID and the rule ID separated by a slash.
+terraform import clo_network_vrouter_nat_rule.ssh 3f2504e0-4f89-41d3-9a0c-0305e82c3301/6ec0bd7f-11c0-43da-975e-2a8ad9ebae0b
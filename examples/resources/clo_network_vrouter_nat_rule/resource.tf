@@ -0,0 +1,8 @@
+# Expose SSH of a private instance on port 2222 of the router's gateway address.
+resource "clo_network_vrouter_nat_rule" "ssh" {
+  vrouter_id       = clo_network_vrouter.router_1.id
+  protocol         = "TCP"
+  external_port    = 2222
+  internal_address = "10.0.0.7"
+  internal_port    = 22
+}
@@ -0,0 +1,2 @@
+# Routes are imported by the router ID and the route ID separated by a slash.
+terraform import clo_network_vrouter_route.to_office 3f2504e0-4f89-41d3-9a0c-0305e82c3301/9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d
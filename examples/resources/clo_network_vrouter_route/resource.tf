@@ -0,0 +1,6 @@
+# Send traffic for 10.20.0.0/24 through an appliance on an attached private network.
+resource "clo_network_vrouter_route" "to_office" {
+  vrouter_id  = clo_network_vrouter.router_1.id
+  destination = "10.20.0.0/24"
+  nexthop     = "10.0.0.5"
+}
@@ -0,0 +1,13 @@
+resource "clo_storage_s3_user_keys" "media" {
+  user_id = clo_storage_s3_user.s3_user.id
+}
+
+# A versioned bucket owned by the user whose keys are passed in. The endpoint
+# comes from the provider's s3_endpoint.
+resource "clo_storage_s3_bucket" "media" {
+  bucket        = "media-assets"
+  access_key    = clo_storage_s3_user_keys.media.access_key
+  secret_key    = clo_storage_s3_user_keys.media.secret_key
+  versioning    = true
+  force_destroy = true
+}
@@ -0,0 +1,20 @@
+resource "clo_storage_s3_bucket_lifecycle" "media" {
+  bucket     = clo_storage_s3_bucket.media.bucket
+  access_key = clo_storage_s3_user_keys.media.access_key
+  secret_key = clo_storage_s3_user_keys.media.secret_key
+
+  # Temporary uploads are removed after a week.
+  rule {
+    id              = "expire-tmp"
+    prefix          = "tmp/"
+    expiration_days = 7
+  }
+
+  # Replaced versions are kept for 30 days, and abandoned multipart uploads are
+  # cleaned up after two.
+  rule {
+    id                                 = "versions-and-uploads"
+    noncurrent_version_expiration_days = 30
+    abort_incomplete_upload_days       = 2
+  }
+}
@@ -0,0 +1,15 @@
+# Allow anonymous reads of everything under public/.
+resource "clo_storage_s3_bucket_policy" "media" {
+  bucket     = clo_storage_s3_bucket.media.bucket
+  access_key = clo_storage_s3_user_keys.media.access_key
+  secret_key = clo_storage_s3_user_keys.media.secret_key
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = "*"
+      Action    = ["s3:GetObject"]
+      Resource  = ["arn:aws:s3:::${clo_storage_s3_bucket.media.bucket}/public/*"]
+    }]
+  })
+}
@@ -0,0 +1,20 @@
+# A bootstrap script uploaded from the module. Editing the file uploads it again.
+resource "clo_storage_s3_object" "bootstrap" {
+  bucket     = clo_storage_s3_bucket.media.bucket
+  key        = "scripts/bootstrap.sh"
+  source     = "${path.module}/files/bootstrap.sh"
+  access_key = clo_storage_s3_user_keys.media.access_key
+  secret_key = clo_storage_s3_user_keys.media.secret_key
+  metadata = {
+    owner = "platform"
+  }
+}
+
+# A small generated document; its content type is guessed from the key.
+resource "clo_storage_s3_object" "config" {
+  bucket     = clo_storage_s3_bucket.media.bucket
+  key        = "config/app.json"
+  content    = jsonencode({ api_url = "https://api.example.com" })
+  access_key = clo_storage_s3_user_keys.media.access_key
+  secret_key = clo_storage_s3_user_keys.media.secret_key
+}
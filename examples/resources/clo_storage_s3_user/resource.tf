@@ -2,5 +2,17 @@ resource "clo_storage_s3_user" "s3_user" {
   project_id          = "e9ff0f-0b8c-4ec5-a0a4-e30cea0db287"
   canonical_name      = "server"
   max_buckets         = 2
-  user_quota_max_size = 30
-}
\ No newline at end of file
+  user_quota_max_size = "500GiB"
+
+  # Each bucket is capped below the user's total.
+  bucket_quota_max_size = "100GiB"
+}
+
+# A user whose access is suspended; their buckets and objects are kept.
+resource "clo_storage_s3_user" "archived" {
+  project_id          = "e9ff0f-0b8c-4ec5-a0a4-e30cea0db287"
+  canonical_name      = "archive"
+  max_buckets         = 1
+  user_quota_max_size = "50GiB"
+  enabled             = false
+}
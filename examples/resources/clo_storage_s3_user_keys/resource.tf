@@ -0,0 +1,15 @@
+resource "clo_storage_s3_user_keys" "s3_userkeys" {
+  user_id = clo_storage_s3_user.s3_user.user_id
+}
+
+# A second pair for another consumer, rotated every 90 days: the replaced pair
+# stays valid as previous_access_key until the rotation after. Rotating needs
+# the provider's preview_endpoints.
+resource "time_rotating" "ci_keys" {
+  rotation_days = 90
+}
+
+resource "clo_storage_s3_user_keys" "ci" {
+  user_id  = clo_storage_s3_user.s3_user.user_id
+  rotation = time_rotating.ci_keys.id
+}
@@ -0,0 +1,90 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Certificate is a TLS certificate uploaded for HTTPS listeners. The private
+// key is write-only: the API never returns it.
+type Certificate struct {
+	ID          string
+	Project     string
+	Name        string
+	Domains     []string
+	Fingerprint string
+	NotAfter    string
+	Status      string
+}
+
+type certificateSchema struct {
+	Id          string   `json:"id"`
+	Project     string   `json:"project"`
+	Name        string   `json:"name"`
+	Domains     []string `json:"domains"`
+	Fingerprint string   `json:"fingerprint"`
+	NotAfter    string   `json:"not_after"`
+	Status      string   `json:"status"`
+}
+
+func certificateFromSchema(r *certificateSchema) Certificate {
+	return Certificate{
+		ID:          r.Id,
+		Project:     r.Project,
+		Name:        r.Name,
+		Domains:     nonNilStrings(r.Domains),
+		Fingerprint: r.Fingerprint,
+		NotAfter:    r.NotAfter,
+		Status:      r.Status,
+	}
+}
+
+// CertificateCreateParams holds the inputs for uploading a certificate.
+// Certificate is the PEM leaf certificate followed by any intermediates.
+type CertificateCreateParams struct {
+	Name        string
+	Certificate string
+	PrivateKey  string
+}
+
+// CreateCertificate uploads a certificate and its key to the project and returns its ID.
+func (c *Client) CreateCertificate(ctx context.Context, projectID string, p CertificateCreateParams) (string, error) {
+	body := struct {
+		Name        string `json:"name"`
+		Certificate string `json:"certificate"`
+		PrivateKey  string `json:"private_key"`
+	}{Name: p.Name, Certificate: p.Certificate, PrivateKey: p.PrivateKey}
+	var out certificateSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/projects/"+projectID+"/certificates", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty certificate create response")
+	}
+	return out.Id, nil
+}
+
+// GetCertificate returns the certificate's current detail.
+func (c *Client) GetCertificate(ctx context.Context, id string) (*Certificate, error) {
+	var out certificateSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/certificates/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	cert := certificateFromSchema(&out)
+	return &cert, nil
+}
+
+// RenameCertificate changes the certificate's name.
+func (c *Client) RenameCertificate(ctx context.Context, id, name string) error {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+	return c.do(ctx, http.MethodPatch, "/v2/certificates/"+id, body, nil)
+}
+
+// DeleteCertificate deletes a certificate. The API refuses while an HTTPS rule
+// still references it.
+func (c *Client) DeleteCertificate(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/certificates/"+id, nil, nil)
+}
@@ -2,25 +2,58 @@
 // (github.com/clo-ru/cloapi-go-client/v3). Resources and data sources call the
 // stable methods defined here instead of the generated client directly, so that
 // changes to generated names/shapes are absorbed in this one package rather than
-// rippling across every resource.
+// rippling across every resource. Endpoints the generated SDK does not model yet
+// go through the small JSON helper in rest.go behind the same kind of methods,
+// as opt-in previews (see do).
 package cloapi
 
-import gen "github.com/clo-ru/cloapi-go-client/v3"
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	gen "github.com/clo-ru/cloapi-go-client/v3"
+)
 
 // Client wraps the generated v3 client behind provider-stable methods.
 type Client struct {
 	gen *gen.ClientWithResponses
+
+	// baseURL, token and http back the rest.go helper for endpoints the
+	// generated client does not cover; preview enables them.
+	baseURL string
+	token   string
+	http    *http.Client
+	preview bool
 }
 
-// New builds an adapter client for the given token and base URL.
-func New(token, baseURL string) (*Client, error) {
+// New builds an adapter client for the given token and base URL. preview
+// enables the endpoints the generated SDK does not model yet.
+func New(token, baseURL string, preview bool) (*Client, error) {
 	g, err := gen.New(token, gen.WithBaseURL(baseURL))
 	if err != nil {
 		return nil, err
 	}
-	return &Client{gen: g}, nil
+	return &Client{
+		gen:     g,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    http.DefaultClient,
+		preview: preview,
+	}, nil
+}
+
+// PreviewEnabled reports whether the client calls preview endpoints.
+func (c *Client) PreviewEnabled() bool {
+	return c.preview
 }
 
 // IsNotFound reports whether err is a 404 from the API. Re-exported so callers
 // (waiters, Read funcs) depend only on this adapter package.
-func IsNotFound(err error) bool { return gen.IsNotFound(err) }
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return gen.IsNotFound(err)
+}
@@ -3,6 +3,9 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
@@ -113,6 +116,47 @@ func (c *Client) DownloadBackup(ctx context.Context, id string) (string, error)
 	return resp.OK.Result.Url, nil
 }
 
+// DownloadError is a non-2xx response from a backup's presigned download URL.
+// It is not an APIError: a 404 there means the link has expired or the object
+// is not in storage yet, not that the backup is gone, so IsNotFound is false.
+type DownloadError struct {
+	BackupID   string
+	StatusCode int
+	Body       string
+}
+
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("cloapi: download backup %s: status %d: %s", e.BackupID, e.StatusCode, e.Body)
+}
+
+// OpenBackupDownload starts downloading the backup from a fresh presigned URL.
+// The caller reads and closes the returned stream.
+func (c *Client) OpenBackupDownload(ctx context.Context, id string) (io.ReadCloser, error) {
+	u, err := c.DownloadBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.openDownload(ctx, id, u)
+}
+
+func (c *Client) openDownload(ctx context.Context, id, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The URL is presigned, so no Authorization header is sent with it.
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, &DownloadError{BackupID: id, StatusCode: resp.StatusCode, Body: string(data)}
+	}
+	return resp.Body, nil
+}
+
 // DeleteBackup deletes a backup. force removes it even when it is the parent of
 // other (incremental) backups.
 func (c *Client) DeleteBackup(ctx context.Context, id string, force bool) error {
@@ -136,6 +180,40 @@ func (c *Client) DisableClusterBackup(ctx context.Context, clusterID string) err
 	return err
 }
 
+// BackupSchedule is a cluster's scheduled-backup policy: backups run daily at
+// Hour (UTC) and the newest Retention scheduled backups are kept.
+type BackupSchedule struct {
+	Enabled   bool
+	Hour      int
+	Retention int
+}
+
+type backupScheduleSchema struct {
+	Enabled   bool `json:"enabled"`
+	Hour      int  `json:"hour"`
+	Retention int  `json:"retention"`
+}
+
+// GetClusterBackupSchedule returns the cluster's scheduled-backup policy.
+func (c *Client) GetClusterBackupSchedule(ctx context.Context, clusterID string) (*BackupSchedule, error) {
+	var out backupScheduleSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/dbaas/clusters/"+clusterID+"/backup_schedule", nil, &out); err != nil {
+		return nil, err
+	}
+	return &BackupSchedule{Enabled: out.Enabled, Hour: out.Hour, Retention: out.Retention}, nil
+}
+
+// UpdateClusterBackupSchedule sets the hour (UTC) scheduled backups run at and
+// how many of them are kept. Enabling and disabling the schedule stays with
+// EnableClusterBackup/DisableClusterBackup.
+func (c *Client) UpdateClusterBackupSchedule(ctx context.Context, clusterID string, hour, retention int) error {
+	body := struct {
+		Hour      int `json:"hour"`
+		Retention int `json:"retention"`
+	}{Hour: hour, Retention: retention}
+	return c.do(ctx, http.MethodPatch, "/v2/dbaas/clusters/"+clusterID+"/backup_schedule", body, nil)
+}
+
 // EnableDatabaseBackup turns scheduled backups on for the database.
 func (c *Client) EnableDatabaseBackup(ctx context.Context, databaseID string) error {
 	_, err := c.gen.DbaasDatabaseBackupEnableWithResponse(ctx, databaseID)
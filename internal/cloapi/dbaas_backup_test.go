@@ -0,0 +1,45 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("presigned download must not send the API token")
+		}
+		if r.URL.Path == "/expired" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "NoSuchKey")
+			return
+		}
+		_, _ = io.WriteString(w, "backup-bytes")
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	body, err := cli.openDownload(context.Background(), "bk-1", srv.URL+"/ok")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "backup-bytes" {
+		t.Errorf("body = %q", data)
+	}
+
+	_, err = cli.openDownload(context.Background(), "bk-1", srv.URL+"/expired")
+	var dlErr *DownloadError
+	if !errors.As(err, &dlErr) || dlErr.StatusCode != http.StatusNotFound || dlErr.BackupID != "bk-1" {
+		t.Fatalf("expected a DownloadError, got %v", err)
+	}
+	if IsNotFound(err) {
+		t.Error("a missing download must not read as a missing backup")
+	}
+}
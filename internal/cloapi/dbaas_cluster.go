@@ -3,6 +3,7 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
@@ -129,6 +130,7 @@ type ClusterCreateParams struct {
 	AddressID         string // optional; empty → address auto-allocated
 	AddressDdos       *bool  // optional; only when allocating a new address
 	RestoreFromBackup string // optional; backup ID to restore into the new cluster
+	RestoreTime       string // optional; RFC3339 point to replay RestoreFromBackup up to
 }
 
 // clusterCreateBody builds the create request body, sending optional fields only
@@ -160,8 +162,64 @@ func clusterCreateBody(p ClusterCreateParams) gen.DbaasClusterCreateJSONRequestB
 	return body
 }
 
-// CreateCluster creates a dbaas cluster in the project and returns its ID.
+// pointInTimeCreateRequest is the create body for a point-in-time restore,
+// which adds restore_time to the fields of the generated create body.
+type pointInTimeCreateRequest struct {
+	Name        string                       `json:"name"`
+	StorageSize int                          `json:"storage_size"`
+	Flavor      clusterFlavorRequest         `json:"flavor"`
+	Datastore   string                       `json:"datastore,omitempty"`
+	Backup      string                       `json:"backup"`
+	RestoreTime string                       `json:"restore_time"`
+	Address     *clusterAddressCreateRequest `json:"address,omitempty"`
+}
+
+type clusterFlavorRequest struct {
+	Ram   int `json:"ram"`
+	Vcpus int `json:"vcpus"`
+}
+
+type clusterAddressCreateRequest struct {
+	DdosProtection *bool  `json:"ddos_protection,omitempty"`
+	Id             string `json:"id,omitempty"`
+}
+
+// pointInTimeCreateBody builds the point-in-time create body, sending the
+// same optional fields as clusterCreateBody only when set.
+func pointInTimeCreateBody(p ClusterCreateParams) pointInTimeCreateRequest {
+	body := pointInTimeCreateRequest{
+		Name:        p.Name,
+		StorageSize: p.StorageSize,
+		Flavor:      clusterFlavorRequest{Ram: p.FlavorRam, Vcpus: p.FlavorVcpus},
+		Datastore:   p.DatastoreID,
+		Backup:      p.RestoreFromBackup,
+		RestoreTime: p.RestoreTime,
+	}
+	if p.AddressID != "" || p.AddressDdos != nil {
+		body.Address = &clusterAddressCreateRequest{DdosProtection: p.AddressDdos, Id: p.AddressID}
+	}
+	return body
+}
+
+// CreateCluster creates a dbaas cluster in the project and returns its ID. With
+// RestoreTime set the cluster is restored from RestoreFromBackup and its logs are
+// replayed up to that moment.
 func (c *Client) CreateCluster(ctx context.Context, projectID string, p ClusterCreateParams) (string, error) {
+	if p.RestoreTime != "" {
+		if p.RestoreFromBackup == "" {
+			return "", errors.New("cloapi: point-in-time restore requires a base backup")
+		}
+		var out struct {
+			ID string `json:"id"`
+		}
+		if err := c.do(ctx, http.MethodPost, "/v2/projects/"+projectID+"/dbaas/clusters", pointInTimeCreateBody(p), &out); err != nil {
+			return "", err
+		}
+		if out.ID == "" {
+			return "", errors.New("cloapi: empty dbaas cluster create response")
+		}
+		return out.ID, nil
+	}
 	resp, err := c.gen.DbaasClusterCreateWithResponse(ctx, projectID, clusterCreateBody(p))
 	if err != nil {
 		return "", err
@@ -237,6 +295,17 @@ func (c *Client) GetClusterConfig(ctx context.Context, clusterID string) (*Clust
 	}, nil
 }
 
+// UpdateClusterConfig sets the given configuration parameters on the cluster,
+// leaving the others unchanged. Values must already have the parameter's type
+// (number, bool, string or list). The cluster goes through UPDATING and ends up
+// ACTIVE, or CONFIG_ERROR when the engine rejects the new configuration.
+func (c *Client) UpdateClusterConfig(ctx context.Context, clusterID string, params map[string]interface{}) error {
+	body := struct {
+		Parameters map[string]interface{} `json:"parameters"`
+	}{Parameters: params}
+	return c.do(ctx, http.MethodPatch, "/v2/dbaas/clusters/"+clusterID+"/config", body, nil)
+}
+
 // ListNodes returns the cluster's member nodes.
 func (c *Client) ListNodes(ctx context.Context, clusterID string) ([]Node, error) {
 	resp, err := c.gen.ClusterDbaasNodesListWithResponse(ctx, clusterID)
@@ -254,6 +323,46 @@ func (c *Client) ListNodes(ctx context.Context, clusterID string) ([]Node, error
 	return out, nil
 }
 
+// AddClusterReplica adds a read replica node to the cluster and returns the new
+// node's ID. The node starts BUILD and becomes ACTIVE once it has caught up.
+func (c *Client) AddClusterReplica(ctx context.Context, clusterID string) (string, error) {
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/v2/dbaas/clusters/"+clusterID+"/nodes", nil, &out); err != nil {
+		return "", err
+	}
+	if out.ID == "" {
+		return "", errors.New("cloapi: empty dbaas node create response")
+	}
+	return out.ID, nil
+}
+
+// DeleteClusterNode removes a replica node. The API refuses to remove the
+// cluster's primary.
+func (c *Client) DeleteClusterNode(ctx context.Context, nodeID string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/dbaas/nodes/"+nodeID, nil, nil)
+}
+
+// SwitchoverCluster promotes the replica nodeID to primary, demoting the current
+// primary to a replica. Nodes go through UPDATING while roles are swapped.
+func (c *Client) SwitchoverCluster(ctx context.Context, clusterID, nodeID string) error {
+	body := struct {
+		NodeID string `json:"node_id"`
+	}{NodeID: nodeID}
+	return c.do(ctx, http.MethodPost, "/v2/dbaas/clusters/"+clusterID+"/switchover", body, nil)
+}
+
+// UpgradeCluster moves the cluster to another datastore version of the same
+// engine. The cluster goes through UPDATING and ends up ACTIVE. The API rejects
+// downgrades and changes of engine or major version.
+func (c *Client) UpgradeCluster(ctx context.Context, id, datastoreID string) error {
+	body := struct {
+		DatastoreID string `json:"datastore_id"`
+	}{DatastoreID: datastoreID}
+	return c.do(ctx, http.MethodPost, "/v2/dbaas/clusters/"+id+"/upgrade", body, nil)
+}
+
 // RenameCluster changes the cluster's name.
 func (c *Client) RenameCluster(ctx context.Context, id, name string) error {
 	_, err := c.gen.DbaasClusterUpdateWithResponse(ctx, id, gen.DbaasClusterUpdateJSONRequestBody{Name: name})
@@ -0,0 +1,190 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateClusterConfigBody(t *testing.T) {
+	var got map[string]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/dbaas/clusters/cl-1/config" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+
+	err := newTestClient(srv).UpdateClusterConfig(context.Background(), "cl-1", map[string]interface{}{
+		"max_connections": float64(200),
+		"autovacuum":      false,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := got["parameters"]
+	if p["max_connections"] != float64(200) || p["autovacuum"] != false || len(p) != 2 {
+		t.Errorf("parameters not sent with their types: %v", got)
+	}
+}
+
+func TestClusterBackupSchedule(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/dbaas/clusters/cl-1/backup_schedule" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"result":{"enabled":true,"hour":3,"retention":14}}`)
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		}
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	s, err := cli.GetClusterBackupSchedule(context.Background(), "cl-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Enabled || s.Hour != 3 || s.Retention != 14 {
+		t.Errorf("schedule mapping wrong: %+v", s)
+	}
+
+	if err := cli.UpdateClusterBackupSchedule(context.Background(), "cl-1", 0, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h, ok := got["hour"]; !ok || h != float64(0) || got["retention"] != float64(7) {
+		t.Errorf("hour 0 must be sent explicitly: %v", got)
+	}
+}
+
+func TestCreateClusterPointInTime(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/projects/p-1/dbaas/clusters" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"cl-2"}}`)
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	id, err := cli.CreateCluster(context.Background(), "p-1", ClusterCreateParams{
+		Name:              "restored",
+		FlavorRam:         4,
+		FlavorVcpus:       2,
+		StorageSize:       20,
+		RestoreFromBackup: "bk-1",
+		RestoreTime:       "2026-10-01T12:30:00Z",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "cl-2" {
+		t.Errorf("id = %q, want cl-2", id)
+	}
+	if got["restore_time"] != "2026-10-01T12:30:00Z" {
+		t.Errorf("restore_time not sent: %v", got)
+	}
+	if got["name"] != "restored" || got["backup"] != "bk-1" || got["storage_size"] != float64(20) {
+		t.Errorf("cluster fields not sent: %v", got)
+	}
+	if fl, _ := got["flavor"].(map[string]interface{}); fl["ram"] != float64(4) || fl["vcpus"] != float64(2) {
+		t.Errorf("flavor not sent: %v", got)
+	}
+	if _, ok := got["datastore"]; ok {
+		t.Errorf("datastore should be omitted when unset: %v", got)
+	}
+	if _, ok := got["address"]; ok {
+		t.Errorf("address should be omitted when unset: %v", got)
+	}
+
+	if _, err := cli.CreateCluster(context.Background(), "p-1", ClusterCreateParams{RestoreTime: "2026-10-01T12:30:00Z"}); err == nil {
+		t.Error("expected an error for a point-in-time restore without a base backup")
+	}
+}
+
+func TestClusterNodeActions(t *testing.T) {
+	var switchover map[string]string
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v2/dbaas/clusters/cl-1/nodes":
+			_, _ = io.WriteString(w, `{"result":{"id":"n-3"}}`)
+		case "/v2/dbaas/clusters/cl-1/switchover":
+			if err := json.NewDecoder(r.Body).Decode(&switchover); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		default:
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		}
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+	ctx := context.Background()
+
+	id, err := cli.AddClusterReplica(ctx, "cl-1")
+	if err != nil || id != "n-3" {
+		t.Fatalf("AddClusterReplica = %q, %v", id, err)
+	}
+	if err := cli.DeleteClusterNode(ctx, "n-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.SwitchoverCluster(ctx, "cl-1", "n-3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"POST /v2/dbaas/clusters/cl-1/nodes",
+		"DELETE /v2/dbaas/nodes/n-2",
+		"POST /v2/dbaas/clusters/cl-1/switchover",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %s, want %s", i, calls[i], want[i])
+		}
+	}
+	if switchover["node_id"] != "n-3" {
+		t.Errorf("node_id not sent: %v", switchover)
+	}
+}
+
+func TestUpgradeClusterBody(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/dbaas/clusters/cl-1/upgrade" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+
+	if err := newTestClient(srv).UpgradeCluster(context.Background(), "cl-1", "ds-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["datastore_id"] != "ds-2" {
+		t.Errorf("datastore_id not sent: %v", got)
+	}
+}
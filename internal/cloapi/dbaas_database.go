@@ -3,6 +3,7 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
@@ -36,14 +37,46 @@ func databaseFromSchema(r *gen.DbaasDatababaseSchema) Database {
 }
 
 // DatabaseCreateParams holds the inputs for adding a database to a cluster.
+// Charset and Collation are optional; empty leaves them to the engine default.
 type DatabaseCreateParams struct {
 	Name          string
 	AdminUsername string
 	AdminPassword string
+	Charset       string
+	Collation     string
+}
+
+// databaseCreateRequest is the create body with a charset or collation, which
+// the generated create body lacks.
+type databaseCreateRequest struct {
+	Name          string `json:"name"`
+	AdminUsername string `json:"admin_username"`
+	AdminPassword string `json:"admin_password"`
+	Charset       string `json:"charset,omitempty"`
+	Collation     string `json:"collation,omitempty"`
 }
 
 // CreateDatabase adds a database to the cluster and returns its ID.
 func (c *Client) CreateDatabase(ctx context.Context, clusterID string, p DatabaseCreateParams) (string, error) {
+	if p.Charset != "" || p.Collation != "" {
+		var out struct {
+			ID string `json:"id"`
+		}
+		body := databaseCreateRequest{
+			Name:          p.Name,
+			AdminUsername: p.AdminUsername,
+			AdminPassword: p.AdminPassword,
+			Charset:       p.Charset,
+			Collation:     p.Collation,
+		}
+		if err := c.do(ctx, http.MethodPost, "/v2/dbaas/clusters/"+clusterID+"/databases", body, &out); err != nil {
+			return "", err
+		}
+		if out.ID == "" {
+			return "", errors.New("cloapi: empty dbaas database create response")
+		}
+		return out.ID, nil
+	}
 	body := gen.ClusterAddDatabaseJSONRequestBody{
 		Name:          p.Name,
 		AdminUsername: p.AdminUsername,
@@ -114,6 +147,16 @@ func (c *Client) RestoreAdminPassword(ctx context.Context, id, password string)
 	return err
 }
 
+// RenameDatabase changes the database's name. Only engines with a rename
+// statement (PostgreSQL) support it; the database goes through UPDATING and ends
+// up READY.
+func (c *Client) RenameDatabase(ctx context.Context, id, name string) error {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+	return c.do(ctx, http.MethodPatch, "/v2/dbaas/databases/"+id, body, nil)
+}
+
 // DeleteDatabase deletes a database.
 func (c *Client) DeleteDatabase(ctx context.Context, id string) error {
 	_, err := c.gen.DbaasDatabaseDeleteWithResponse(ctx, id)
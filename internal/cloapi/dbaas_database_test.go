@@ -0,0 +1,65 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateDatabaseWithCharset(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/dbaas/clusters/cl-1/databases" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"db-1"}}`)
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	id, err := cli.CreateDatabase(context.Background(), "cl-1", DatabaseCreateParams{
+		Name:          "app",
+		AdminUsername: "app_admin",
+		AdminPassword: "pw",
+		Charset:       "utf8mb4",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "db-1" {
+		t.Errorf("id = %q, want db-1", id)
+	}
+	if got["name"] != "app" || got["admin_username"] != "app_admin" || got["charset"] != "utf8mb4" {
+		t.Errorf("unexpected body: %v", got)
+	}
+	if _, ok := got["collation"]; ok {
+		t.Errorf("empty collation must be left to the engine default: %v", got)
+	}
+}
+
+func TestRenameDatabase(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/dbaas/databases/db-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+
+	if err := newTestClient(srv).RenameDatabase(context.Background(), "db-1", "app_v2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["name"] != "app_v2" {
+		t.Errorf("name = %q, want app_v2", got["name"])
+	}
+}
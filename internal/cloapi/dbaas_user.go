@@ -0,0 +1,126 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// DbaasUser is an additional login on a dbaas cluster, independent of the
+// per-database admin users. Host is the MySQL-style host mask the login is
+// allowed from ("%" for any host). The password is write-only: the API never
+// returns it.
+type DbaasUser struct {
+	ID        string
+	ClusterID string
+	Name      string
+	Host      string
+	Status    string
+	CreatedIn string
+}
+
+// DbaasGrant is the set of privileges a dbaas user holds on one database.
+type DbaasGrant struct {
+	DatabaseID string
+	Privileges []string
+}
+
+type dbaasUserSchema struct {
+	Id        string `json:"id"`
+	ClusterId string `json:"cluster_id"`
+	Name      string `json:"name"`
+	Host      string `json:"host"`
+	Status    string `json:"status"`
+	CreatedIn string `json:"created_in"`
+}
+
+type dbaasGrantSchema struct {
+	DatabaseId string   `json:"database_id"`
+	Privileges []string `json:"privileges"`
+}
+
+func dbaasUserFromSchema(r *dbaasUserSchema) DbaasUser {
+	return DbaasUser{
+		ID:        r.Id,
+		ClusterID: r.ClusterId,
+		Name:      r.Name,
+		Host:      r.Host,
+		Status:    r.Status,
+		CreatedIn: r.CreatedIn,
+	}
+}
+
+// DbaasUserCreateParams holds the inputs for adding a user to a cluster. An
+// empty Host leaves the server default (any host).
+type DbaasUserCreateParams struct {
+	Name     string
+	Password string
+	Host     string
+}
+
+// CreateDbaasUser adds a user to the cluster and returns its ID.
+func (c *Client) CreateDbaasUser(ctx context.Context, clusterID string, p DbaasUserCreateParams) (string, error) {
+	body := struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		Host     string `json:"host,omitempty"`
+	}{Name: p.Name, Password: p.Password, Host: p.Host}
+	var out dbaasUserSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/dbaas/clusters/"+clusterID+"/users", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty dbaas user create response")
+	}
+	return out.Id, nil
+}
+
+// GetDbaasUser returns the user's current detail.
+func (c *Client) GetDbaasUser(ctx context.Context, id string) (*DbaasUser, error) {
+	var out dbaasUserSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/dbaas/users/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	u := dbaasUserFromSchema(&out)
+	return &u, nil
+}
+
+// ChangeDbaasUserPassword sets a new password for the user.
+func (c *Client) ChangeDbaasUserPassword(ctx context.Context, id, password string) error {
+	body := struct {
+		Password string `json:"password"`
+	}{Password: password}
+	return c.do(ctx, http.MethodPatch, "/v2/dbaas/users/"+id, body, nil)
+}
+
+// DeleteDbaasUser deletes the user together with all of its grants.
+func (c *Client) DeleteDbaasUser(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/dbaas/users/"+id, nil, nil)
+}
+
+// ListDbaasUserGrants returns the privileges the user currently holds, one
+// entry per database.
+func (c *Client) ListDbaasUserGrants(ctx context.Context, userID string) ([]DbaasGrant, error) {
+	var out []dbaasGrantSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/dbaas/users/"+userID+"/grants", nil, &out); err != nil {
+		return nil, err
+	}
+	grants := make([]DbaasGrant, 0, len(out))
+	for _, g := range out {
+		grants = append(grants, DbaasGrant{DatabaseID: g.DatabaseId, Privileges: nonNilStrings(g.Privileges)})
+	}
+	return grants, nil
+}
+
+// SetDbaasGrant replaces the user's privileges on the database with privileges.
+func (c *Client) SetDbaasGrant(ctx context.Context, userID, databaseID string, privileges []string) error {
+	body := struct {
+		Privileges []string `json:"privileges"`
+	}{Privileges: nonNilStrings(privileges)}
+	return c.do(ctx, http.MethodPut, "/v2/dbaas/users/"+userID+"/grants/"+databaseID, body, nil)
+}
+
+// RevokeDbaasGrant removes all of the user's privileges on the database.
+func (c *Client) RevokeDbaasGrant(ctx context.Context, userID, databaseID string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/dbaas/users/"+userID+"/grants/"+databaseID, nil, nil)
+}
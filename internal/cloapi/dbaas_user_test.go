@@ -0,0 +1,78 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCreateDbaasUserBody(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/dbaas/clusters/cl-1/users" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"u-1"}}`)
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	id, err := cli.CreateDbaasUser(context.Background(), "cl-1", DbaasUserCreateParams{Name: "app_ro", Password: "pw"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "u-1" {
+		t.Errorf("id = %q, want u-1", id)
+	}
+	if got["name"] != "app_ro" || got["password"] != "pw" {
+		t.Errorf("name/password not sent: %v", got)
+	}
+	if _, ok := got["host"]; ok {
+		t.Errorf("empty host must be left to the server default: %v", got)
+	}
+}
+
+func TestDbaasUserGrants(t *testing.T) {
+	var put map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/dbaas/users/u-1/grants":
+			_, _ = io.WriteString(w, `{"result":[{"database_id":"db-1","privileges":["SELECT","INSERT"]},{"database_id":"db-2","privileges":null}]}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/dbaas/users/u-1/grants/db-1":
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	grants, err := cli.ListDbaasUserGrants(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []DbaasGrant{
+		{DatabaseID: "db-1", Privileges: []string{"SELECT", "INSERT"}},
+		{DatabaseID: "db-2", Privileges: []string{}},
+	}
+	if !reflect.DeepEqual(grants, want) {
+		t.Errorf("grants = %v, want %v", grants, want)
+	}
+
+	if err := cli.SetDbaasGrant(context.Background(), "u-1", "db-1", []string{"SELECT"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(put["privileges"], []string{"SELECT"}) {
+		t.Errorf("privileges not sent: %v", put)
+	}
+}
@@ -3,6 +3,7 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
@@ -48,6 +49,21 @@ type Rule struct {
 	InternalProtocolPort int
 }
 
+// RuleListener holds a rule's listener options. Protocol is TCP, HTTP or
+// HTTPS; CertificateID is set for HTTPS listeners that terminate TLS on the
+// balancer. XForwardedFor and RedirectHTTPS apply to the HTTP protocols only.
+type RuleListener struct {
+	Protocol      string
+	CertificateID string
+	XForwardedFor bool
+	RedirectHTTPS bool
+}
+
+// IsDefault reports whether l is the plain TCP forward every rule starts as.
+func (l RuleListener) IsDefault() bool {
+	return (l.Protocol == "" || l.Protocol == "TCP") && l.CertificateID == "" && !l.XForwardedFor && !l.RedirectHTTPS
+}
+
 func loadBalancerFromSchema(r *gen.LBDetailResponseSchema) LoadBalancer {
 	lb := LoadBalancer{
 		ID:                 r.Id,
@@ -99,6 +115,59 @@ func ruleFromSchema(r *gen.RuleDetailResponseSchema) Rule {
 	}
 }
 
+// The generated SDK models rules with ports only; the listener options are
+// sent with the create and read from the rule's listener sub-resource.
+
+type ruleListenerSchema struct {
+	Protocol      string  `json:"protocol"`
+	CertificateId *string `json:"certificate_id,omitempty"`
+	XForwardedFor bool    `json:"x_forwarded_for"`
+	RedirectHttps bool    `json:"redirect_http_to_https"`
+}
+
+// ruleCreateRequest is the create body with listener options, which the
+// generated create body lacks.
+type ruleCreateRequest struct {
+	AddressId            string `json:"address_id"`
+	ExternalProtocolPort int    `json:"external_protocol_port"`
+	InternalProtocolPort int    `json:"internal_protocol_port"`
+	ruleListenerSchema
+}
+
+// ruleListenerBody builds the wire listener options. An empty protocol is sent
+// as TCP and the certificate only when set.
+func ruleListenerBody(l RuleListener) ruleListenerSchema {
+	body := ruleListenerSchema{
+		Protocol:      l.Protocol,
+		XForwardedFor: l.XForwardedFor,
+		RedirectHttps: l.RedirectHTTPS,
+	}
+	if body.Protocol == "" {
+		body.Protocol = "TCP"
+	}
+	if l.CertificateID != "" {
+		body.CertificateId = &l.CertificateID
+	}
+	return body
+}
+
+func ruleListenerFromSchema(r *ruleListenerSchema) RuleListener {
+	l := RuleListener{
+		Protocol:      r.Protocol,
+		XForwardedFor: r.XForwardedFor,
+		RedirectHTTPS: r.RedirectHttps,
+	}
+	// Rules whose listener was never set report no protocol; they are plain
+	// TCP forwards.
+	if l.Protocol == "" {
+		l.Protocol = "TCP"
+	}
+	if r.CertificateId != nil {
+		l.CertificateID = *r.CertificateId
+	}
+	return l
+}
+
 // HealthmonitorParams holds the health-check inputs for create/update.
 type HealthmonitorParams struct {
 	Type          string
@@ -120,11 +189,14 @@ type LoadBalancerCreateParams struct {
 	Healthmonitor      HealthmonitorParams
 }
 
-// RuleCreateParams holds the inputs for creating a listener rule.
+// RuleCreateParams holds the inputs for creating a listener rule. A Listener
+// other than the plain TCP forward is sent with the create, so the rule never
+// serves without it.
 type RuleCreateParams struct {
 	AddressID            string
 	ExternalProtocolPort int
 	InternalProtocolPort int
+	Listener             RuleListener
 }
 
 // loadBalancerCreateBody builds the create request body, sending optional fields
@@ -165,8 +237,8 @@ func loadBalancerCreateBody(p LoadBalancerCreateParams) gen.LoadBalancerCreateJS
 	}
 
 	// The API derives a rule quota from this field and errors on a missing
-	// (null) rules key; rules are managed as standalone resources, so always
-	// send an explicit empty list.
+	// (null) rules key; rules (standalone or inline) are created with CreateRule
+	// once the balancer is up, so always send an explicit empty list.
 	body.Rules = &[]struct {
 		AddressId            string `json:"address_id"`
 		ExternalProtocolPort int    `json:"external_protocol_port"`
@@ -283,6 +355,24 @@ func (c *Client) DeleteLoadBalancer(ctx context.Context, id string) error {
 
 // CreateRule creates a listener rule on the load balancer and returns its ID.
 func (c *Client) CreateRule(ctx context.Context, loadBalancerID string, p RuleCreateParams) (string, error) {
+	if !p.Listener.IsDefault() {
+		var out struct {
+			ID string `json:"id"`
+		}
+		body := ruleCreateRequest{
+			AddressId:            p.AddressID,
+			ExternalProtocolPort: p.ExternalProtocolPort,
+			InternalProtocolPort: p.InternalProtocolPort,
+			ruleListenerSchema:   ruleListenerBody(p.Listener),
+		}
+		if err := c.do(ctx, http.MethodPost, "/v2/loadbalancers/"+loadBalancerID+"/rules", body, &out); err != nil {
+			return "", err
+		}
+		if out.ID == "" {
+			return "", errors.New("cloapi: empty rule create response")
+		}
+		return out.ID, nil
+	}
 	resp, err := c.gen.RuleCreateWithResponse(ctx, loadBalancerID, gen.RuleCreateJSONRequestBody{
 		AddressId:            p.AddressID,
 		ExternalProtocolPort: p.ExternalProtocolPort,
@@ -327,6 +417,15 @@ func (c *Client) ListRules(ctx context.Context, loadBalancerID string) ([]Rule,
 	return out, nil
 }
 
+// GetRuleListener returns the rule's listener options.
+func (c *Client) GetRuleListener(ctx context.Context, ruleID string) (RuleListener, error) {
+	var out ruleListenerSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/rules/"+ruleID+"/listener", nil, &out); err != nil {
+		return RuleListener{}, err
+	}
+	return ruleListenerFromSchema(&out), nil
+}
+
 // DeleteRule deletes a listener rule.
 func (c *Client) DeleteRule(ctx context.Context, id string) error {
 	_, err := c.gen.RuleDeleteWithResponse(ctx, id)
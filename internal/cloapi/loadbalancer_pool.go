@@ -0,0 +1,192 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Pool is a load balancer listener that spreads the traffic arriving on
+// ExternalProtocolPort across several backend members, unlike a Rule which
+// forwards to a single server.
+type Pool struct {
+	ID                   string
+	Loadbalancer         string
+	Name                 string
+	ExternalProtocolPort int
+	Status               string
+	Members              []PoolMember
+}
+
+// PoolMember is one backend of a pool. AdminState is what the operator asked
+// for (ENABLED/DRAINING/DISABLED); OperatingStatus is what the load balancer's
+// healthmonitor last observed (ONLINE/OFFLINE/DEGRADED/NO_MONITOR).
+type PoolMember struct {
+	ID              string
+	AddressID       string
+	Address         string
+	Server          string
+	Port            int
+	Weight          int
+	AdminState      string
+	Status          string
+	OperatingStatus string
+}
+
+type poolSchema struct {
+	Id                   string             `json:"id"`
+	Loadbalancer         string             `json:"loadbalancer"`
+	Name                 string             `json:"name"`
+	ExternalProtocolPort int                `json:"external_protocol_port"`
+	Status               string             `json:"status"`
+	Members              []poolMemberSchema `json:"members"`
+}
+
+type poolMemberSchema struct {
+	Id              string `json:"id"`
+	AddressId       string `json:"address_id"`
+	Address         string `json:"address"`
+	Server          string `json:"server"`
+	ProtocolPort    int    `json:"protocol_port"`
+	Weight          int    `json:"weight"`
+	AdminState      string `json:"admin_state"`
+	Status          string `json:"status"`
+	OperatingStatus string `json:"operating_status"`
+}
+
+type poolMemberBody struct {
+	AddressId    string `json:"address_id,omitempty"`
+	ProtocolPort int    `json:"protocol_port,omitempty"`
+	Weight       int    `json:"weight"`
+	AdminState   string `json:"admin_state"`
+}
+
+func poolFromSchema(r *poolSchema) Pool {
+	p := Pool{
+		ID:                   r.Id,
+		Loadbalancer:         r.Loadbalancer,
+		Name:                 r.Name,
+		ExternalProtocolPort: r.ExternalProtocolPort,
+		Status:               r.Status,
+	}
+	for i := range r.Members {
+		p.Members = append(p.Members, poolMemberFromSchema(&r.Members[i]))
+	}
+	return p
+}
+
+func poolMemberFromSchema(r *poolMemberSchema) PoolMember {
+	return PoolMember{
+		ID:              r.Id,
+		AddressID:       r.AddressId,
+		Address:         r.Address,
+		Server:          r.Server,
+		Port:            r.ProtocolPort,
+		Weight:          r.Weight,
+		AdminState:      r.AdminState,
+		Status:          r.Status,
+		OperatingStatus: r.OperatingStatus,
+	}
+}
+
+// PoolMemberParams describes a pool member on create. Weight and AdminState
+// are also what UpdatePoolMember changes in place.
+type PoolMemberParams struct {
+	AddressID  string
+	Port       int
+	Weight     int
+	AdminState string
+}
+
+// PoolCreateParams holds the inputs for creating a pool.
+type PoolCreateParams struct {
+	Name                 string
+	ExternalProtocolPort int
+	Members              []PoolMemberParams
+}
+
+// CreatePool creates a pool on the load balancer, members included, and returns its ID.
+func (c *Client) CreatePool(ctx context.Context, loadBalancerID string, p PoolCreateParams) (string, error) {
+	body := struct {
+		Name                 string           `json:"name"`
+		ExternalProtocolPort int              `json:"external_protocol_port"`
+		Members              []poolMemberBody `json:"members"`
+	}{Name: p.Name, ExternalProtocolPort: p.ExternalProtocolPort, Members: make([]poolMemberBody, 0, len(p.Members))}
+	for _, m := range p.Members {
+		body.Members = append(body.Members, poolMemberBody{
+			AddressId:    m.AddressID,
+			ProtocolPort: m.Port,
+			Weight:       m.Weight,
+			AdminState:   m.AdminState,
+		})
+	}
+	var out poolSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/loadbalancers/"+loadBalancerID+"/pools", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty pool create response")
+	}
+	return out.Id, nil
+}
+
+// GetPool returns the pool's current detail, members and their health included.
+func (c *Client) GetPool(ctx context.Context, id string) (*Pool, error) {
+	var out poolSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/pools/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	p := poolFromSchema(&out)
+	return &p, nil
+}
+
+// ListPools returns the load balancer's pools (single page, matching the other list adapters).
+func (c *Client) ListPools(ctx context.Context, loadBalancerID string) ([]Pool, error) {
+	var items []poolSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/loadbalancers/"+loadBalancerID+"/pools", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]Pool, 0, len(items))
+	for i := range items {
+		out = append(out, poolFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// RenamePool changes the pool's name.
+func (c *Client) RenamePool(ctx context.Context, id, name string) error {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+	return c.do(ctx, http.MethodPatch, "/v2/pools/"+id, body, nil)
+}
+
+// DeletePool deletes a pool and its members.
+func (c *Client) DeletePool(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/pools/"+id, nil, nil)
+}
+
+// AddPoolMember adds a backend to the pool and returns the member ID.
+func (c *Client) AddPoolMember(ctx context.Context, poolID string, p PoolMemberParams) (string, error) {
+	body := poolMemberBody{AddressId: p.AddressID, ProtocolPort: p.Port, Weight: p.Weight, AdminState: p.AdminState}
+	var out poolMemberSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/pools/"+poolID+"/members", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty pool member create response")
+	}
+	return out.Id, nil
+}
+
+// UpdatePoolMember changes a member's weight and admin state in place. The
+// address and port of a member cannot change.
+func (c *Client) UpdatePoolMember(ctx context.Context, poolID, id string, weight int, adminState string) error {
+	body := poolMemberBody{Weight: weight, AdminState: adminState}
+	return c.do(ctx, http.MethodPatch, "/v2/pools/"+poolID+"/members/"+id, body, nil)
+}
+
+// RemovePoolMember removes a backend from the pool.
+func (c *Client) RemovePoolMember(ctx context.Context, poolID, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/pools/"+poolID+"/members/"+id, nil, nil)
+}
@@ -0,0 +1,66 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdatePoolMemberBody(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/pools/pool-1/members/m-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+
+	if err := newTestClient(srv).UpdatePoolMember(context.Background(), "pool-1", "m-1", 5, "DRAINING"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["weight"] != float64(5) || got["admin_state"] != "DRAINING" {
+		t.Errorf("weight/admin_state not sent: %v", got)
+	}
+	if _, ok := got["address_id"]; ok {
+		t.Errorf("address_id is immutable and must not be sent on update: %v", got)
+	}
+	if _, ok := got["protocol_port"]; ok {
+		t.Errorf("protocol_port is immutable and must not be sent on update: %v", got)
+	}
+}
+
+func TestGetPool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/pools/pool-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"pool-1","loadbalancer":"lb-1","name":"web",
+			"external_protocol_port":443,"status":"ACTIVE","members":[{"id":"m-1","address_id":"a-1",
+			"address":"10.0.0.5","server":"srv-1","protocol_port":8443,"weight":3,"admin_state":"ENABLED",
+			"status":"ACTIVE","operating_status":"DEGRADED"}]}}`)
+	}))
+	defer srv.Close()
+
+	p, err := newTestClient(srv).GetPool(context.Background(), "pool-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ID != "pool-1" || p.Loadbalancer != "lb-1" || p.Name != "web" || p.ExternalProtocolPort != 443 || p.Status != "ACTIVE" {
+		t.Errorf("pool mapping wrong: %+v", p)
+	}
+	if len(p.Members) != 1 {
+		t.Fatalf("expected 1 member, got %+v", p.Members)
+	}
+	m := p.Members[0]
+	if m.ID != "m-1" || m.AddressID != "a-1" || m.Address != "10.0.0.5" || m.Server != "srv-1" || m.Port != 8443 ||
+		m.Weight != 3 || m.AdminState != "ENABLED" || m.Status != "ACTIVE" || m.OperatingStatus != "DEGRADED" {
+		t.Errorf("member mapping wrong: %+v", m)
+	}
+}
@@ -0,0 +1,89 @@
+package cloapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// LoadBalancerStatus is the load balancer's health tree: its own operating
+// status and, per listener rule, whether the backend passes the healthmonitor.
+type LoadBalancerStatus struct {
+	ID                 string
+	ProvisioningStatus string
+	OperatingStatus    string
+	Rules              []RuleStatus
+}
+
+// RuleStatus is the health of one listener rule's backend. OperatingStatus is
+// ONLINE, OFFLINE, DEGRADED, ERROR or NO_MONITOR; the LastCheck fields describe
+// the most recent healthmonitor probe and are empty until the first one runs.
+type RuleStatus struct {
+	ID                   string
+	Address              string
+	Server               string
+	ExternalProtocolPort int
+	InternalProtocolPort int
+	ProvisioningStatus   string
+	OperatingStatus      string
+	LastCheckResult      string
+	LastCheckAt          string
+	LastCheckDetail      string
+}
+
+type loadBalancerStatusSchema struct {
+	Id                 string             `json:"id"`
+	ProvisioningStatus string             `json:"provisioning_status"`
+	OperatingStatus    string             `json:"operating_status"`
+	Rules              []ruleStatusSchema `json:"rules"`
+}
+
+type ruleStatusSchema struct {
+	Id                   string `json:"id"`
+	Address              string `json:"address"`
+	Server               string `json:"server"`
+	ExternalProtocolPort int    `json:"external_protocol_port"`
+	InternalProtocolPort int    `json:"internal_protocol_port"`
+	ProvisioningStatus   string `json:"provisioning_status"`
+	OperatingStatus      string `json:"operating_status"`
+	LastCheck            *struct {
+		Result    string `json:"result"`
+		CheckedAt string `json:"checked_at"`
+		Detail    string `json:"detail"`
+	} `json:"last_check"`
+}
+
+func loadBalancerStatusFromSchema(r *loadBalancerStatusSchema) LoadBalancerStatus {
+	st := LoadBalancerStatus{
+		ID:                 r.Id,
+		ProvisioningStatus: r.ProvisioningStatus,
+		OperatingStatus:    r.OperatingStatus,
+	}
+	for _, rs := range r.Rules {
+		rule := RuleStatus{
+			ID:                   rs.Id,
+			Address:              rs.Address,
+			Server:               rs.Server,
+			ExternalProtocolPort: rs.ExternalProtocolPort,
+			InternalProtocolPort: rs.InternalProtocolPort,
+			ProvisioningStatus:   rs.ProvisioningStatus,
+			OperatingStatus:      rs.OperatingStatus,
+		}
+		if rs.LastCheck != nil {
+			rule.LastCheckResult = rs.LastCheck.Result
+			rule.LastCheckAt = rs.LastCheck.CheckedAt
+			rule.LastCheckDetail = rs.LastCheck.Detail
+		}
+		st.Rules = append(st.Rules, rule)
+	}
+	return st
+}
+
+// GetLoadBalancerStatus returns the load balancer's current health tree.
+func (c *Client) GetLoadBalancerStatus(ctx context.Context, id string) (*LoadBalancerStatus, error) {
+	var out loadBalancerStatusSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/loadbalancers/"+id+"/status", nil, &out); err != nil {
+		return nil, err
+	}
+	st := loadBalancerStatusFromSchema(&out)
+	return &st, nil
+}
@@ -0,0 +1,44 @@
+package cloapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLoadBalancerStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/loadbalancers/lb-1/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"lb-1","provisioning_status":"ACTIVE","operating_status":"DEGRADED",
+			"rules":[
+				{"id":"r-1","address":"10.0.0.5","server":"srv-1","external_protocol_port":80,"internal_protocol_port":8080,
+				 "provisioning_status":"ACTIVE","operating_status":"OFFLINE",
+				 "last_check":{"result":"FAIL","checked_at":"2024-06-07T08:09:10Z","detail":"connection refused"}},
+				{"id":"r-2","provisioning_status":"CREATING","operating_status":"NO_MONITOR","last_check":null}
+			]}}`)
+	}))
+	defer srv.Close()
+
+	st, err := newTestClient(srv).GetLoadBalancerStatus(context.Background(), "lb-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.ID != "lb-1" || st.ProvisioningStatus != "ACTIVE" || st.OperatingStatus != "DEGRADED" || len(st.Rules) != 2 {
+		t.Fatalf("status mapping wrong: %+v", st)
+	}
+	r := st.Rules[0]
+	if r.ID != "r-1" || r.Address != "10.0.0.5" || r.Server != "srv-1" || r.ExternalProtocolPort != 80 ||
+		r.InternalProtocolPort != 8080 || r.OperatingStatus != "OFFLINE" {
+		t.Errorf("rule mapping wrong: %+v", r)
+	}
+	if r.LastCheckResult != "FAIL" || r.LastCheckAt != "2024-06-07T08:09:10Z" || r.LastCheckDetail != "connection refused" {
+		t.Errorf("last check mapping wrong: %+v", r)
+	}
+	if r := st.Rules[1]; r.LastCheckResult != "" || r.LastCheckAt != "" {
+		t.Errorf("missing last check should map to empty fields: %+v", r)
+	}
+}
@@ -1,6 +1,11 @@
 package cloapi
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -109,6 +114,61 @@ func TestRuleFromSchema(t *testing.T) {
 	}
 }
 
+func TestRuleListener(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/loadbalancers/lb-1/rules":
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			_, _ = io.WriteString(w, `{"result":{"id":"rule-1"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/rules/rule-1/listener":
+			_, _ = io.WriteString(w, `{"result":{"protocol":"HTTPS","certificate_id":"cert-1","x_forwarded_for":true}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/rules/rule-2/listener":
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	id, err := cli.CreateRule(context.Background(), "lb-1", RuleCreateParams{
+		AddressID:            "addr-1",
+		ExternalProtocolPort: 80,
+		InternalProtocolPort: 8080,
+		Listener:             RuleListener{XForwardedFor: true},
+	})
+	if err != nil || id != "rule-1" {
+		t.Fatalf("create: %q, %v", id, err)
+	}
+	if got["address_id"] != "addr-1" || got["external_protocol_port"] != float64(80) || got["internal_protocol_port"] != float64(8080) {
+		t.Errorf("ports and address should be sent with the listener: %v", got)
+	}
+	if got["protocol"] != "TCP" || got["x_forwarded_for"] != true {
+		t.Errorf("protocol should default to TCP: %v", got)
+	}
+	if _, ok := got["certificate_id"]; ok {
+		t.Errorf("certificate_id should be omitted when unset: %v", got)
+	}
+
+	l, err := cli.GetRuleListener(context.Background(), "rule-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if l.Protocol != "HTTPS" || l.CertificateID != "cert-1" || !l.XForwardedFor || l.RedirectHTTPS {
+		t.Errorf("listener mapping wrong: %+v", l)
+	}
+	unset, err := cli.GetRuleListener(context.Background(), "rule-2")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if unset.Protocol != "TCP" || !unset.IsDefault() {
+		t.Errorf("listener without protocol should read as plain TCP: %+v", unset)
+	}
+}
+
 func TestLoadBalancerCreateBody(t *testing.T) {
 	t.Run("minimal_omits_optionals", func(t *testing.T) {
 		body := loadBalancerCreateBody(LoadBalancerCreateParams{
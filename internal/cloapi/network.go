@@ -0,0 +1,291 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// PrivateNetwork is the provider-facing view of an isolated private network.
+// Addressing lives on its subnets; Subnets holds their IDs.
+type PrivateNetwork struct {
+	ID      string
+	Name    string
+	Project string
+	Status  string
+	Subnets []string
+}
+
+// Subnet is an address range inside a private network. GatewayIP is empty for
+// a subnet without a gateway.
+type Subnet struct {
+	ID             string
+	Network        string
+	Name           string
+	CIDR           string
+	GatewayIP      string
+	EnableDHCP     bool
+	DNSNameservers []string
+	Status         string
+}
+
+// ServerInterface is a NIC of a compute instance plugged into a private network.
+type ServerInterface struct {
+	ID         string
+	Server     string
+	Network    string
+	Subnet     string
+	Address    string
+	MacAddress string
+	Status     string
+}
+
+type privateNetworkSchema struct {
+	Id      string   `json:"id"`
+	Name    string   `json:"name"`
+	Project string   `json:"project"`
+	Status  string   `json:"status"`
+	Subnets []string `json:"subnets"`
+}
+
+type subnetSchema struct {
+	Id             string   `json:"id"`
+	Network        string   `json:"network"`
+	Name           string   `json:"name"`
+	Cidr           string   `json:"cidr"`
+	GatewayIp      *string  `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+	Status         string   `json:"status"`
+}
+
+type serverInterfaceSchema struct {
+	Id         string `json:"id"`
+	Server     string `json:"server"`
+	Network    string `json:"network"`
+	Subnet     string `json:"subnet"`
+	Address    string `json:"address"`
+	MacAddress string `json:"mac_address"`
+	Status     string `json:"status"`
+}
+
+func privateNetworkFromSchema(r *privateNetworkSchema) PrivateNetwork {
+	return PrivateNetwork{
+		ID:      r.Id,
+		Name:    r.Name,
+		Project: r.Project,
+		Status:  r.Status,
+		Subnets: append([]string(nil), r.Subnets...),
+	}
+}
+
+func subnetFromSchema(r *subnetSchema) Subnet {
+	s := Subnet{
+		ID:             r.Id,
+		Network:        r.Network,
+		Name:           r.Name,
+		CIDR:           r.Cidr,
+		EnableDHCP:     r.EnableDhcp,
+		DNSNameservers: append([]string(nil), r.DnsNameservers...),
+		Status:         r.Status,
+	}
+	if r.GatewayIp != nil {
+		s.GatewayIP = *r.GatewayIp
+	}
+	return s
+}
+
+func serverInterfaceFromSchema(r *serverInterfaceSchema) ServerInterface {
+	return ServerInterface{
+		ID:         r.Id,
+		Server:     r.Server,
+		Network:    r.Network,
+		Subnet:     r.Subnet,
+		Address:    r.Address,
+		MacAddress: r.MacAddress,
+		Status:     r.Status,
+	}
+}
+
+// CreatePrivateNetwork creates a private network in the project and returns its ID.
+func (c *Client) CreatePrivateNetwork(ctx context.Context, projectID, name string) (string, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+	var out privateNetworkSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/projects/"+projectID+"/networks", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty private network create response")
+	}
+	return out.Id, nil
+}
+
+// GetPrivateNetwork returns the private network's current detail.
+func (c *Client) GetPrivateNetwork(ctx context.Context, id string) (*PrivateNetwork, error) {
+	var out privateNetworkSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/networks/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	n := privateNetworkFromSchema(&out)
+	return &n, nil
+}
+
+// ListPrivateNetworks returns the project's private networks (single page, matching the other list adapters).
+func (c *Client) ListPrivateNetworks(ctx context.Context, projectID string) ([]PrivateNetwork, error) {
+	var items []privateNetworkSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/projects/"+projectID+"/networks", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]PrivateNetwork, 0, len(items))
+	for i := range items {
+		out = append(out, privateNetworkFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// RenamePrivateNetwork changes the private network's name.
+func (c *Client) RenamePrivateNetwork(ctx context.Context, id, name string) error {
+	body := struct {
+		Name string `json:"name"`
+	}{Name: name}
+	return c.do(ctx, http.MethodPatch, "/v2/networks/"+id, body, nil)
+}
+
+// DeletePrivateNetwork deletes a private network. The API refuses while it
+// still has subnets or plugged interfaces.
+func (c *Client) DeletePrivateNetwork(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/networks/"+id, nil, nil)
+}
+
+// SubnetCreateParams holds the inputs for creating a subnet. An empty GatewayIP
+// lets the API pick the first address of CIDR.
+type SubnetCreateParams struct {
+	Name           string
+	CIDR           string
+	GatewayIP      string
+	EnableDHCP     bool
+	DNSNameservers []string
+}
+
+// SubnetUpdateParams holds the subnet settings that can change in place.
+type SubnetUpdateParams struct {
+	Name           string
+	EnableDHCP     bool
+	DNSNameservers []string
+}
+
+// CreateSubnet creates a subnet in the private network and returns its ID.
+func (c *Client) CreateSubnet(ctx context.Context, networkID string, p SubnetCreateParams) (string, error) {
+	body := struct {
+		Name           string   `json:"name,omitempty"`
+		Cidr           string   `json:"cidr"`
+		GatewayIp      *string  `json:"gateway_ip,omitempty"`
+		EnableDhcp     bool     `json:"enable_dhcp"`
+		DnsNameservers []string `json:"dns_nameservers"`
+	}{Name: p.Name, Cidr: p.CIDR, EnableDhcp: p.EnableDHCP, DnsNameservers: nonNilStrings(p.DNSNameservers)}
+	if p.GatewayIP != "" {
+		gw := p.GatewayIP
+		body.GatewayIp = &gw
+	}
+	var out subnetSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/networks/"+networkID+"/subnets", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty subnet create response")
+	}
+	return out.Id, nil
+}
+
+// GetSubnet returns the subnet's current detail.
+func (c *Client) GetSubnet(ctx context.Context, id string) (*Subnet, error) {
+	var out subnetSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/subnets/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	s := subnetFromSchema(&out)
+	return &s, nil
+}
+
+// ListSubnets returns the private network's subnets.
+func (c *Client) ListSubnets(ctx context.Context, networkID string) ([]Subnet, error) {
+	var items []subnetSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/networks/"+networkID+"/subnets", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]Subnet, 0, len(items))
+	for i := range items {
+		out = append(out, subnetFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// UpdateSubnet changes the subnet's name, DHCP and DNS settings.
+func (c *Client) UpdateSubnet(ctx context.Context, id string, p SubnetUpdateParams) error {
+	body := struct {
+		Name           string   `json:"name"`
+		EnableDhcp     bool     `json:"enable_dhcp"`
+		DnsNameservers []string `json:"dns_nameservers"`
+	}{Name: p.Name, EnableDhcp: p.EnableDHCP, DnsNameservers: nonNilStrings(p.DNSNameservers)}
+	return c.do(ctx, http.MethodPatch, "/v2/subnets/"+id, body, nil)
+}
+
+// DeleteSubnet deletes a subnet.
+func (c *Client) DeleteSubnet(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/subnets/"+id, nil, nil)
+}
+
+// ServerInterfaceParams describes a NIC to plug into a private network. An
+// empty SubnetID picks the network's first subnet; an empty Address lets DHCP
+// allocate one.
+type ServerInterfaceParams struct {
+	NetworkID string
+	SubnetID  string
+	Address   string
+}
+
+// AttachServerInterface plugs a new NIC into the instance and returns its ID.
+func (c *Client) AttachServerInterface(ctx context.Context, serverID string, p ServerInterfaceParams) (string, error) {
+	body := struct {
+		Network string `json:"network"`
+		Subnet  string `json:"subnet,omitempty"`
+		Address string `json:"address,omitempty"`
+	}{Network: p.NetworkID, Subnet: p.SubnetID, Address: p.Address}
+	var out serverInterfaceSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/servers/"+serverID+"/interfaces", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty server interface create response")
+	}
+	return out.Id, nil
+}
+
+// ListServerInterfaces returns the private-network NICs plugged into the instance.
+func (c *Client) ListServerInterfaces(ctx context.Context, serverID string) ([]ServerInterface, error) {
+	var items []serverInterfaceSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/servers/"+serverID+"/interfaces", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]ServerInterface, 0, len(items))
+	for i := range items {
+		out = append(out, serverInterfaceFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// DetachServerInterface unplugs a NIC from the instance.
+func (c *Client) DetachServerInterface(ctx context.Context, serverID, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/servers/"+serverID+"/interfaces/"+id, nil, nil)
+}
+
+// nonNilStrings keeps an empty list serialising as [] rather than null, so an
+// update can clear the field.
+func nonNilStrings(in []string) []string {
+	if in == nil {
+		return []string{}
+	}
+	return in
+}
@@ -0,0 +1,61 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSubnetBody(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/networks/net-1/subnets" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"sn-1"}}`)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv).CreateSubnet(context.Background(), "net-1", SubnetCreateParams{
+		CIDR:       "10.0.1.0/24",
+		EnableDHCP: false,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["gateway_ip"]; ok {
+		t.Errorf("unset gateway must be omitted so the API picks one: %v", got)
+	}
+	if dhcp, ok := got["enable_dhcp"]; !ok || dhcp != false {
+		t.Errorf("enable_dhcp=false must be sent explicitly: %v", got)
+	}
+	if dns, ok := got["dns_nameservers"].([]interface{}); !ok || len(dns) != 0 {
+		t.Errorf("empty dns_nameservers must be sent as []: %v", got)
+	}
+}
+
+func TestSubnetFromSchema(t *testing.T) {
+	gw := "10.0.1.1"
+	s := subnetFromSchema(&subnetSchema{
+		Id:             "sn-1",
+		Network:        "net-1",
+		Cidr:           "10.0.1.0/24",
+		GatewayIp:      &gw,
+		EnableDhcp:     true,
+		DnsNameservers: []string{"8.8.8.8"},
+		Status:         "ACTIVE",
+	})
+	if s.ID != "sn-1" || s.Network != "net-1" || s.CIDR != "10.0.1.0/24" || s.GatewayIP != "10.0.1.1" ||
+		!s.EnableDHCP || len(s.DNSNameservers) != 1 || s.Status != "ACTIVE" {
+		t.Errorf("subnet mapping wrong: %+v", s)
+	}
+	if s := subnetFromSchema(&subnetSchema{Id: "sn-2"}); s.GatewayIP != "" {
+		t.Errorf("missing gateway should map to empty: %+v", s)
+	}
+}
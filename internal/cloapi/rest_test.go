@@ -0,0 +1,118 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient points the rest.go helper at srv, with preview endpoints
+// enabled. The generated client is left nil: only methods built on do may be
+// exercised with it.
+func newTestClient(srv *httptest.Server) *Client {
+	return &Client{baseURL: srv.URL, token: "tok", http: srv.Client(), preview: true}
+}
+
+func TestDo(t *testing.T) {
+	t.Run("sends_auth_and_body_decodes_result", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+				t.Errorf("authorization header wrong: %q", got)
+			}
+			if r.Method != http.MethodPost || r.URL.Path != "/v2/vrouters/vr-1/routes" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["destination"] != "10.1.0.0/24" || body["nexthop"] != "10.0.0.5" {
+				t.Errorf("body wrong: %v", body)
+			}
+			_, _ = io.WriteString(w, `{"result":{"id":"rt-1","vrouter":"vr-1","destination":"10.1.0.0/24","nexthop":"10.0.0.5","status":"ACTIVE"}}`)
+		}))
+		defer srv.Close()
+
+		id, err := newTestClient(srv).CreateVrouterRoute(context.Background(), "vr-1", VrouterRouteCreateParams{
+			Destination: "10.1.0.0/24",
+			Nexthop:     "10.0.0.5",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if id != "rt-1" {
+			t.Errorf("id wrong: %q", id)
+		}
+	})
+
+	t.Run("not_found_is_recognised", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		_, err := newTestClient(srv).GetVrouterRoute(context.Background(), "vr-1", "rt-1")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !IsNotFound(err) {
+			t.Errorf("404 should be reported as not found: %v", err)
+		}
+	})
+
+	t.Run("server_error_is_not_not_found", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		err := newTestClient(srv).DeleteVrouterRoute(context.Background(), "vr-1", "rt-1")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if IsNotFound(err) {
+			t.Errorf("500 must not be reported as not found: %v", err)
+		}
+	})
+
+	t.Run("preview_disabled_sends_nothing", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer srv.Close()
+		cli := newTestClient(srv)
+		cli.preview = false
+
+		_, err := cli.GetVrouterRoute(context.Background(), "vr-1", "rt-1")
+		if !errors.Is(err, ErrPreviewDisabled) {
+			t.Fatalf("expected ErrPreviewDisabled, got %v", err)
+		}
+		if IsNotFound(err) {
+			t.Errorf("a disabled preview must not be reported as not found: %v", err)
+		}
+	})
+
+	t.Run("list_decodes_items", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"result":[{"id":"nat-1","vrouter":"vr-1","protocol":"TCP","external_port":2222,"internal_address":"10.0.0.7","internal_port":22,"status":"ACTIVE"}]}`)
+		}))
+		defer srv.Close()
+
+		rules, err := newTestClient(srv).ListVrouterNatRules(context.Background(), "vr-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(rules) != 1 {
+			t.Fatalf("expected one rule, got %d", len(rules))
+		}
+		r := rules[0]
+		if r.ID != "nat-1" || r.Vrouter != "vr-1" || r.Protocol != "TCP" || r.ExternalPort != 2222 ||
+			r.InternalAddress != "10.0.0.7" || r.InternalPort != 22 || r.Status != "ACTIVE" {
+			t.Errorf("nat rule mapping wrong: %+v", r)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
 )
@@ -64,13 +65,18 @@ type S3UserCreateParams struct {
 	BucketQuotaMaxObjects int
 }
 
-// S3UserQuotaParams describes a quota update.
+// S3UserQuotaParams describes a quota update. Nil fields are not sent, so the
+// server keeps their current values.
 type S3UserQuotaParams struct {
-	MaxBuckets            int
-	UserQuotaMaxSize      int
-	UserQuotaMaxObjects   int
-	BucketQuotaMaxSize    int
-	BucketQuotaMaxObjects int
+	MaxBuckets  *int
+	UserQuota   *S3QuotaLimits
+	BucketQuota *S3QuotaLimits
+}
+
+// S3QuotaLimits are the limits of one quota type. Zero means no limit.
+type S3QuotaLimits struct {
+	MaxSize    int
+	MaxObjects int
 }
 
 // S3Keys are an access/secret key pair. SecretKey is only returned on generation.
@@ -159,30 +165,46 @@ func (c *Client) UpdateS3UserName(ctx context.Context, id, name string) error {
 	return err
 }
 
-// UpdateS3UserQuota updates the user's quotas.
+// UpdateS3UserQuota updates the quota types set in p and leaves the others
+// alone.
 func (c *Client) UpdateS3UserQuota(ctx context.Context, id string, p S3UserQuotaParams) error {
-	body := gen.S3UserUpdateQuotaJSONRequestBody{MaxBuckets: intPtr(p.MaxBuckets)}
-	body.UserQuota = &struct {
-		MaxObjects *int `json:"max_objects,omitempty"`
-		MaxSize    int  `json:"max_size"`
-	}{MaxSize: p.UserQuotaMaxSize}
-	if p.UserQuotaMaxObjects > 0 {
-		body.UserQuota.MaxObjects = intPtr(p.UserQuotaMaxObjects)
-	}
-	body.BucketQuota = &struct {
-		MaxObjects *int `json:"max_objects,omitempty"`
-		MaxSize    *int `json:"max_size,omitempty"`
-	}{}
-	if p.BucketQuotaMaxSize > 0 {
-		body.BucketQuota.MaxSize = intPtr(p.BucketQuotaMaxSize)
+	body := gen.S3UserUpdateQuotaJSONRequestBody{MaxBuckets: p.MaxBuckets}
+	if q := p.UserQuota; q != nil {
+		body.UserQuota = &struct {
+			MaxObjects *int `json:"max_objects,omitempty"`
+			MaxSize    int  `json:"max_size"`
+		}{MaxSize: q.MaxSize}
+		if q.MaxObjects > 0 {
+			body.UserQuota.MaxObjects = intPtr(q.MaxObjects)
+		}
 	}
-	if p.BucketQuotaMaxObjects > 0 {
-		body.BucketQuota.MaxObjects = intPtr(p.BucketQuotaMaxObjects)
+	if q := p.BucketQuota; q != nil {
+		body.BucketQuota = &struct {
+			MaxObjects *int `json:"max_objects,omitempty"`
+			MaxSize    *int `json:"max_size,omitempty"`
+		}{MaxSize: intPtr(q.MaxSize)}
+		// max_size is always sent, so that 0 lifts the size limit rather
+		// than leaving the old one in place.
+		if q.MaxObjects > 0 {
+			body.BucketQuota.MaxObjects = intPtr(q.MaxObjects)
+		}
 	}
 	_, err := c.gen.S3UserUpdateQuotaWithResponse(ctx, id, body)
 	return err
 }
 
+// SuspendS3User blocks the user's access to the storage without deleting their
+// buckets. The user goes through SUSPENDING and ends up SUSPENDED.
+func (c *Client) SuspendS3User(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/v2/s3/users/"+id+"/suspend", nil, nil)
+}
+
+// ResumeS3User restores a suspended user's access. The user goes through
+// RESUMING and ends up AVAILABLE.
+func (c *Client) ResumeS3User(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/v2/s3/users/"+id+"/resume", nil, nil)
+}
+
 // DeleteS3User deletes the user.
 func (c *Client) DeleteS3User(ctx context.Context, id string) error {
 	_, err := c.gen.S3UserDeleteWithResponse(ctx, id)
@@ -0,0 +1,68 @@
+package cloapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// S3Key is one of an object-storage user's access key pairs. A user may hold
+// several pairs at once, so consumers can move to a new pair before the old one
+// is revoked. SecretKey is only returned when the pair is created.
+type S3Key struct {
+	ID        string
+	UserID    string
+	AccessKey string
+	SecretKey string
+	CreatedIn string
+}
+
+type s3KeySchema struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	CreatedIn string `json:"created_in"`
+}
+
+func s3KeyFromSchema(r *s3KeySchema) S3Key {
+	return S3Key{
+		ID:        r.Id,
+		UserID:    r.UserId,
+		AccessKey: r.AccessKey,
+		SecretKey: r.SecretKey,
+		CreatedIn: r.CreatedIn,
+	}
+}
+
+// CreateS3UserKey issues an additional key pair for the user. Unlike
+// GenS3UserKeys, the user's other pairs stay valid.
+func (c *Client) CreateS3UserKey(ctx context.Context, userID string) (*S3Key, error) {
+	var out s3KeySchema
+	if err := c.do(ctx, http.MethodPost, "/v2/s3/users/"+userID+"/keys", nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Id == "" || out.AccessKey == "" {
+		return nil, errors.New("cloapi: empty s3 key create response")
+	}
+	k := s3KeyFromSchema(&out)
+	return &k, nil
+}
+
+// ListS3UserKeys returns the user's key pairs, without their secret keys.
+func (c *Client) ListS3UserKeys(ctx context.Context, userID string) ([]S3Key, error) {
+	var out []s3KeySchema
+	if err := c.do(ctx, http.MethodGet, "/v2/s3/users/"+userID+"/keys", nil, &out); err != nil {
+		return nil, err
+	}
+	keys := make([]S3Key, 0, len(out))
+	for i := range out {
+		keys = append(keys, s3KeyFromSchema(&out[i]))
+	}
+	return keys, nil
+}
+
+// RevokeS3UserKey revokes one key pair. Requests signed with it fail from then on.
+func (c *Client) RevokeS3UserKey(ctx context.Context, keyID string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/s3/keys/"+keyID, nil, nil)
+}
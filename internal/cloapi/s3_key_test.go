@@ -0,0 +1,76 @@
+package cloapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestS3UserKeys(t *testing.T) {
+	var revoked string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/s3/users/u-1/keys":
+			_, _ = io.WriteString(w, `{"result":{"id":"k-2","user_id":"u-1","access_key":"AK2","secret_key":"SK2","created_in":"2026-10-19T10:00:00Z"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/s3/users/u-1/keys":
+			_, _ = io.WriteString(w, `{"result":[{"id":"k-1","user_id":"u-1","access_key":"AK1"},{"id":"k-2","user_id":"u-1","access_key":"AK2"}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/s3/keys/k-1":
+			revoked = "k-1"
+			_, _ = io.WriteString(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+	ctx := context.Background()
+
+	key, err := cli.CreateS3UserKey(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("CreateS3UserKey: %v", err)
+	}
+	if key.ID != "k-2" || key.AccessKey != "AK2" || key.SecretKey != "SK2" {
+		t.Errorf("unexpected key %+v", key)
+	}
+
+	keys, err := cli.ListS3UserKeys(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("ListS3UserKeys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "k-1" || keys[1].AccessKey != "AK2" || keys[1].SecretKey != "" {
+		t.Errorf("unexpected keys %+v", keys)
+	}
+
+	if err := cli.RevokeS3UserKey(ctx, "k-1"); err != nil {
+		t.Fatalf("RevokeS3UserKey: %v", err)
+	}
+	if revoked != "k-1" {
+		t.Error("key was not revoked")
+	}
+}
+
+func TestSuspendResumeS3User(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		calls = append(calls, r.URL.Path)
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+	cli := newTestClient(srv)
+
+	if err := cli.SuspendS3User(context.Background(), "u-1"); err != nil {
+		t.Fatalf("SuspendS3User: %v", err)
+	}
+	if err := cli.ResumeS3User(context.Background(), "u-1"); err != nil {
+		t.Fatalf("ResumeS3User: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "/v2/s3/users/u-1/suspend" || calls[1] != "/v2/s3/users/u-1/resume" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
@@ -0,0 +1,39 @@
+package cloapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSecurityGroupRuleBody(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/security_groups/sg-1/rules" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = io.WriteString(w, `{"result":{"id":"r-1"}}`)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv).CreateSecurityGroupRule(context.Background(), "sg-1", SecurityGroupRuleCreateParams{
+		Direction:  "EGRESS",
+		Protocol:   "ANY",
+		RemoteCIDR: "0.0.0.0/0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["port_range_min"]; ok {
+		t.Errorf("zero ports must be omitted: %v", got)
+	}
+	if got["direction"] != "EGRESS" || got["protocol"] != "ANY" || got["remote_cidr"] != "0.0.0.0/0" {
+		t.Errorf("body wrong: %v", got)
+	}
+}
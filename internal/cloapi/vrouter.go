@@ -3,6 +3,7 @@ package cloapi
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	gen "github.com/clo-ru/cloapi-go-client/v3"
 )
@@ -106,3 +107,170 @@ func (c *Client) DeleteVrouter(ctx context.Context, id string) error {
 	_, err := c.gen.VrouterDeleteWithResponse(ctx, id)
 	return err
 }
+
+// VrouterRoute is a static route on a virtual router: traffic for Destination
+// (a CIDR) is forwarded to the Nexthop address.
+type VrouterRoute struct {
+	ID          string
+	Vrouter     string
+	Destination string
+	Nexthop     string
+	Status      string
+}
+
+// VrouterNatRule is a port-forwarding (DNAT) rule on a virtual router: traffic
+// arriving at ExternalPort on the router's gateway address is forwarded to
+// InternalPort on InternalAddress.
+type VrouterNatRule struct {
+	ID              string
+	Vrouter         string
+	Protocol        string
+	ExternalPort    int
+	InternalAddress string
+	InternalPort    int
+	Status          string
+}
+
+type vrouterRouteSchema struct {
+	Id          string `json:"id"`
+	Vrouter     string `json:"vrouter"`
+	Destination string `json:"destination"`
+	Nexthop     string `json:"nexthop"`
+	Status      string `json:"status"`
+}
+
+type vrouterNatRuleSchema struct {
+	Id              string `json:"id"`
+	Vrouter         string `json:"vrouter"`
+	Protocol        string `json:"protocol"`
+	ExternalPort    int    `json:"external_port"`
+	InternalAddress string `json:"internal_address"`
+	InternalPort    int    `json:"internal_port"`
+	Status          string `json:"status"`
+}
+
+func vrouterRouteFromSchema(r *vrouterRouteSchema) VrouterRoute {
+	return VrouterRoute{
+		ID:          r.Id,
+		Vrouter:     r.Vrouter,
+		Destination: r.Destination,
+		Nexthop:     r.Nexthop,
+		Status:      r.Status,
+	}
+}
+
+func vrouterNatRuleFromSchema(r *vrouterNatRuleSchema) VrouterNatRule {
+	return VrouterNatRule{
+		ID:              r.Id,
+		Vrouter:         r.Vrouter,
+		Protocol:        r.Protocol,
+		ExternalPort:    r.ExternalPort,
+		InternalAddress: r.InternalAddress,
+		InternalPort:    r.InternalPort,
+		Status:          r.Status,
+	}
+}
+
+// VrouterRouteCreateParams holds the inputs for adding a static route.
+type VrouterRouteCreateParams struct {
+	Destination string
+	Nexthop     string
+}
+
+// VrouterNatRuleCreateParams holds the inputs for adding a port-forwarding rule.
+type VrouterNatRuleCreateParams struct {
+	Protocol        string
+	ExternalPort    int
+	InternalAddress string
+	InternalPort    int
+}
+
+// CreateVrouterRoute adds a static route to the virtual router and returns its ID.
+func (c *Client) CreateVrouterRoute(ctx context.Context, vrouterID string, p VrouterRouteCreateParams) (string, error) {
+	body := struct {
+		Destination string `json:"destination"`
+		Nexthop     string `json:"nexthop"`
+	}{Destination: p.Destination, Nexthop: p.Nexthop}
+	var out vrouterRouteSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/vrouters/"+vrouterID+"/routes", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty vrouter route create response")
+	}
+	return out.Id, nil
+}
+
+// GetVrouterRoute returns the static route's current detail.
+func (c *Client) GetVrouterRoute(ctx context.Context, vrouterID, id string) (*VrouterRoute, error) {
+	var out vrouterRouteSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/vrouters/"+vrouterID+"/routes/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	r := vrouterRouteFromSchema(&out)
+	return &r, nil
+}
+
+// ListVrouterRoutes returns the virtual router's static routes.
+func (c *Client) ListVrouterRoutes(ctx context.Context, vrouterID string) ([]VrouterRoute, error) {
+	var items []vrouterRouteSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/vrouters/"+vrouterID+"/routes", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]VrouterRoute, 0, len(items))
+	for i := range items {
+		out = append(out, vrouterRouteFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// DeleteVrouterRoute removes a static route from the virtual router.
+func (c *Client) DeleteVrouterRoute(ctx context.Context, vrouterID, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/vrouters/"+vrouterID+"/routes/"+id, nil, nil)
+}
+
+// CreateVrouterNatRule adds a port-forwarding rule to the virtual router and returns its ID.
+func (c *Client) CreateVrouterNatRule(ctx context.Context, vrouterID string, p VrouterNatRuleCreateParams) (string, error) {
+	body := struct {
+		Protocol        string `json:"protocol"`
+		ExternalPort    int    `json:"external_port"`
+		InternalAddress string `json:"internal_address"`
+		InternalPort    int    `json:"internal_port"`
+	}{Protocol: p.Protocol, ExternalPort: p.ExternalPort, InternalAddress: p.InternalAddress, InternalPort: p.InternalPort}
+	var out vrouterNatRuleSchema
+	if err := c.do(ctx, http.MethodPost, "/v2/vrouters/"+vrouterID+"/nat_rules", body, &out); err != nil {
+		return "", err
+	}
+	if out.Id == "" {
+		return "", errors.New("cloapi: empty vrouter nat rule create response")
+	}
+	return out.Id, nil
+}
+
+// GetVrouterNatRule returns the port-forwarding rule's current detail.
+func (c *Client) GetVrouterNatRule(ctx context.Context, vrouterID, id string) (*VrouterNatRule, error) {
+	var out vrouterNatRuleSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/vrouters/"+vrouterID+"/nat_rules/"+id, nil, &out); err != nil {
+		return nil, err
+	}
+	r := vrouterNatRuleFromSchema(&out)
+	return &r, nil
+}
+
+// ListVrouterNatRules returns the virtual router's port-forwarding rules.
+func (c *Client) ListVrouterNatRules(ctx context.Context, vrouterID string) ([]VrouterNatRule, error) {
+	var items []vrouterNatRuleSchema
+	if err := c.do(ctx, http.MethodGet, "/v2/vrouters/"+vrouterID+"/nat_rules", nil, &items); err != nil {
+		return nil, err
+	}
+	out := make([]VrouterNatRule, 0, len(items))
+	for i := range items {
+		out = append(out, vrouterNatRuleFromSchema(&items[i]))
+	}
+	return out, nil
+}
+
+// DeleteVrouterNatRule removes a port-forwarding rule from the virtual router.
+func (c *Client) DeleteVrouterNatRule(ctx context.Context, vrouterID, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/vrouters/"+vrouterID+"/nat_rules/"+id, nil, nil)
+}
@@ -0,0 +1,347 @@
+package s3api
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Bucket versioning states as S3 reports them. A bucket that never had
+// versioning enabled reports an empty status.
+const (
+	VersioningEnabled   = "Enabled"
+	VersioningSuspended = "Suspended"
+)
+
+// CreateBucket creates a bucket owned by the client's user.
+func (c *Client) CreateBucket(ctx context.Context, bucket string) error {
+	_, err := c.call(ctx, request{method: http.MethodPut, bucket: bucket}, nil)
+	return err
+}
+
+// HeadBucket checks that the bucket exists and is accessible with the client's
+// keys. A missing bucket is reported as a not-found error.
+func (c *Client) HeadBucket(ctx context.Context, bucket string) error {
+	_, err := c.call(ctx, request{method: http.MethodHead, bucket: bucket}, nil)
+	return err
+}
+
+// DeleteBucket deletes an empty bucket.
+func (c *Client) DeleteBucket(ctx context.Context, bucket string) error {
+	_, err := c.call(ctx, request{method: http.MethodDelete, bucket: bucket}, nil)
+	return err
+}
+
+// BucketSummary is one entry of a bucket listing.
+type BucketSummary struct {
+	Name         string
+	CreationDate time.Time
+}
+
+// ListBuckets returns the buckets owned by the client's user.
+func (c *Client) ListBuckets(ctx context.Context) ([]BucketSummary, error) {
+	var out struct {
+		Buckets []struct {
+			Name         string    `xml:"Name"`
+			CreationDate time.Time `xml:"CreationDate"`
+		} `xml:"Buckets>Bucket"`
+	}
+	if _, err := c.call(ctx, request{method: http.MethodGet}, &out); err != nil {
+		return nil, err
+	}
+	buckets := make([]BucketSummary, 0, len(out.Buckets))
+	for _, b := range out.Buckets {
+		buckets = append(buckets, BucketSummary{Name: b.Name, CreationDate: b.CreationDate})
+	}
+	return buckets, nil
+}
+
+type versioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+// GetBucketVersioning returns the bucket's versioning status: VersioningEnabled,
+// VersioningSuspended or empty.
+func (c *Client) GetBucketVersioning(ctx context.Context, bucket string) (string, error) {
+	var out versioningConfiguration
+	if _, err := c.call(ctx, request{method: http.MethodGet, bucket: bucket, query: subresource("versioning")}, &out); err != nil {
+		return "", err
+	}
+	return out.Status, nil
+}
+
+// PutBucketVersioning sets the bucket's versioning status. Once enabled,
+// versioning can only be suspended, not turned off.
+func (c *Client) PutBucketVersioning(ctx context.Context, bucket, status string) error {
+	return c.putSubresource(ctx, bucket, "versioning", versioningConfiguration{Status: status})
+}
+
+// GetBucketPolicy returns the bucket's policy document. A bucket without a
+// policy is reported as a not-found error.
+func (c *Client) GetBucketPolicy(ctx context.Context, bucket string) (string, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, bucket: bucket, query: subresource("policy")})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// PutBucketPolicy replaces the bucket's policy with the JSON document.
+func (c *Client) PutBucketPolicy(ctx context.Context, bucket, policy string) error {
+	return c.putBody(ctx, bucket, "policy", []byte(policy), "application/json")
+}
+
+// DeleteBucketPolicy removes the bucket's policy.
+func (c *Client) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	_, err := c.call(ctx, request{method: http.MethodDelete, bucket: bucket, query: subresource("policy")}, nil)
+	return err
+}
+
+// LifecycleRule is one rule of a bucket's lifecycle configuration. Zero day
+// counts leave the action out.
+type LifecycleRule struct {
+	ID      string
+	Prefix  string
+	Enabled bool
+	// ExpirationDays expires current object versions this many days after
+	// creation.
+	ExpirationDays int
+	// NoncurrentVersionExpirationDays deletes versions this many days after they
+	// stop being current.
+	NoncurrentVersionExpirationDays int
+	// AbortIncompleteUploadDays aborts multipart uploads not completed this many
+	// days after they were started.
+	AbortIncompleteUploadDays int
+}
+
+type lifecycleConfiguration struct {
+	XMLName xml.Name           `xml:"LifecycleConfiguration"`
+	Rules   []lifecycleXMLRule `xml:"Rule"`
+}
+
+type lifecycleXMLRule struct {
+	ID string `xml:"ID"`
+	// LegacyPrefix is the pre-Filter way of scoping a rule; some servers still
+	// return it.
+	LegacyPrefix string `xml:"Prefix,omitempty"`
+	Filter       *struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"Filter"`
+	Status     string `xml:"Status"`
+	Expiration *struct {
+		Days int `xml:"Days"`
+	} `xml:"Expiration"`
+	NoncurrentVersionExpiration *struct {
+		NoncurrentDays int `xml:"NoncurrentDays"`
+	} `xml:"NoncurrentVersionExpiration"`
+	AbortIncompleteMultipartUpload *struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	} `xml:"AbortIncompleteMultipartUpload"`
+}
+
+// GetBucketLifecycle returns the bucket's lifecycle rules. A bucket without a
+// lifecycle configuration is reported as a not-found error.
+func (c *Client) GetBucketLifecycle(ctx context.Context, bucket string) ([]LifecycleRule, error) {
+	var out lifecycleConfiguration
+	if _, err := c.call(ctx, request{method: http.MethodGet, bucket: bucket, query: subresource("lifecycle")}, &out); err != nil {
+		return nil, err
+	}
+	rules := make([]LifecycleRule, 0, len(out.Rules))
+	for _, x := range out.Rules {
+		r := LifecycleRule{ID: x.ID, Prefix: x.LegacyPrefix, Enabled: x.Status == "Enabled"}
+		if x.Filter != nil {
+			r.Prefix = x.Filter.Prefix
+		}
+		if x.Expiration != nil {
+			r.ExpirationDays = x.Expiration.Days
+		}
+		if x.NoncurrentVersionExpiration != nil {
+			r.NoncurrentVersionExpirationDays = x.NoncurrentVersionExpiration.NoncurrentDays
+		}
+		if x.AbortIncompleteMultipartUpload != nil {
+			r.AbortIncompleteUploadDays = x.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// PutBucketLifecycle replaces the bucket's lifecycle configuration.
+func (c *Client) PutBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) error {
+	cfg := lifecycleConfiguration{}
+	for _, r := range rules {
+		x := lifecycleXMLRule{ID: r.ID, Status: "Disabled"}
+		if r.Enabled {
+			x.Status = "Enabled"
+		}
+		x.Filter = &struct {
+			Prefix string `xml:"Prefix"`
+		}{Prefix: r.Prefix}
+		if r.ExpirationDays > 0 {
+			x.Expiration = &struct {
+				Days int `xml:"Days"`
+			}{Days: r.ExpirationDays}
+		}
+		if r.NoncurrentVersionExpirationDays > 0 {
+			x.NoncurrentVersionExpiration = &struct {
+				NoncurrentDays int `xml:"NoncurrentDays"`
+			}{NoncurrentDays: r.NoncurrentVersionExpirationDays}
+		}
+		if r.AbortIncompleteUploadDays > 0 {
+			x.AbortIncompleteMultipartUpload = &struct {
+				DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+			}{DaysAfterInitiation: r.AbortIncompleteUploadDays}
+		}
+		cfg.Rules = append(cfg.Rules, x)
+	}
+	return c.putSubresource(ctx, bucket, "lifecycle", cfg)
+}
+
+// DeleteBucketLifecycle removes the bucket's lifecycle configuration.
+func (c *Client) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	_, err := c.call(ctx, request{method: http.MethodDelete, bucket: bucket, query: subresource("lifecycle")}, nil)
+	return err
+}
+
+// ObjectSummary is an entry of a bucket listing. ETag is unquoted.
+type ObjectSummary struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// ListObjects returns every object whose key starts with prefix, following
+// continuation tokens until the listing is complete.
+func (c *Client) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectSummary, error) {
+	var out []ObjectSummary
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			q.Set("prefix", prefix)
+		}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		var page struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				ETag         string    `xml:"ETag"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if _, err := c.call(ctx, request{method: http.MethodGet, bucket: bucket, query: q}, &page); err != nil {
+			return nil, err
+		}
+		for _, o := range page.Contents {
+			out = append(out, ObjectSummary{Key: o.Key, Size: o.Size, ETag: strings.Trim(o.ETag, `"`), LastModified: o.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// ObjectVersion is a version or delete marker of an object. Unversioned
+// objects have the version ID "null".
+type ObjectVersion struct {
+	Key          string
+	VersionID    string
+	Size         int64
+	DeleteMarker bool
+}
+
+// ListObjectVersions returns every version and delete marker in the bucket.
+func (c *Client) ListObjectVersions(ctx context.Context, bucket string) ([]ObjectVersion, error) {
+	var out []ObjectVersion
+	keyMarker, versionMarker := "", ""
+	for {
+		q := subresource("versions")
+		if keyMarker != "" {
+			q.Set("key-marker", keyMarker)
+			q.Set("version-id-marker", versionMarker)
+		}
+		type entry struct {
+			Key       string `xml:"Key"`
+			VersionID string `xml:"VersionId"`
+			Size      int64  `xml:"Size"`
+		}
+		var page struct {
+			Versions            []entry `xml:"Version"`
+			DeleteMarkers       []entry `xml:"DeleteMarker"`
+			IsTruncated         bool    `xml:"IsTruncated"`
+			NextKeyMarker       string  `xml:"NextKeyMarker"`
+			NextVersionIDMarker string  `xml:"NextVersionIdMarker"`
+		}
+		if _, err := c.call(ctx, request{method: http.MethodGet, bucket: bucket, query: q}, &page); err != nil {
+			return nil, err
+		}
+		for _, v := range page.Versions {
+			out = append(out, ObjectVersion{Key: v.Key, VersionID: v.VersionID, Size: v.Size})
+		}
+		for _, v := range page.DeleteMarkers {
+			out = append(out, ObjectVersion{Key: v.Key, VersionID: v.VersionID, DeleteMarker: true})
+		}
+		if !page.IsTruncated || page.NextKeyMarker == "" {
+			return out, nil
+		}
+		keyMarker, versionMarker = page.NextKeyMarker, page.NextVersionIDMarker
+	}
+}
+
+// DeleteObjectVersion permanently deletes one version or delete marker.
+func (c *Client) DeleteObjectVersion(ctx context.Context, bucket, key, versionID string) error {
+	q := url.Values{"versionId": {versionID}}
+	_, err := c.call(ctx, request{method: http.MethodDelete, bucket: bucket, key: key, query: q}, nil)
+	return err
+}
+
+// subresource is the query of a bucket sub-resource such as "?policy".
+func subresource(name string) url.Values {
+	return url.Values{name: {""}}
+}
+
+func (c *Client) putSubresource(ctx context.Context, bucket, name string, v interface{}) error {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.putBody(ctx, bucket, name, append([]byte(xml.Header), data...), "application/xml")
+}
+
+// putBody uploads a small sub-resource document. The body is signed, and sent
+// with the Content-MD5 header some of these calls require.
+func (c *Client) putBody(ctx context.Context, bucket, name string, body []byte, contentType string) error {
+	sum := md5.Sum(body)
+	h := http.Header{}
+	h.Set("Content-Type", contentType)
+	h.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	_, err := c.call(ctx, request{
+		method:      http.MethodPut,
+		bucket:      bucket,
+		query:       subresource(name),
+		header:      h,
+		body:        bytes.NewReader(body),
+		size:        int64(len(body)),
+		payloadHash: hexSHA256(body),
+	}, nil)
+	return err
+}
@@ -0,0 +1,154 @@
+package s3api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/clo-ru/terraform-provider-clo/v2/internal/s3api/s3test"
+)
+
+func TestBucketLifecycle(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	cli, err := New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := cli.CreateBucket(ctx, "media"); err != nil {
+		t.Fatalf("CreateBucket: %v", err)
+	}
+	if err := cli.HeadBucket(ctx, "media"); err != nil {
+		t.Fatalf("HeadBucket: %v", err)
+	}
+	if err := cli.HeadBucket(ctx, "missing"); !IsNotFound(err) {
+		t.Errorf("expected not found for a missing bucket, got %v", err)
+	}
+
+	if status, err := cli.GetBucketVersioning(ctx, "media"); err != nil || status != "" {
+		t.Errorf("initial versioning = %q, %v; want empty", status, err)
+	}
+	if err := cli.PutBucketVersioning(ctx, "media", VersioningEnabled); err != nil {
+		t.Fatalf("PutBucketVersioning: %v", err)
+	}
+	if status, err := cli.GetBucketVersioning(ctx, "media"); err != nil || status != VersioningEnabled {
+		t.Errorf("versioning = %q, %v; want Enabled", status, err)
+	}
+
+	if _, err := cli.GetBucketPolicy(ctx, "media"); !IsNotFound(err) {
+		t.Errorf("expected not found for a missing policy, got %v", err)
+	}
+	policy := `{"Version":"2012-10-17","Statement":[]}`
+	if err := cli.PutBucketPolicy(ctx, "media", policy); err != nil {
+		t.Fatalf("PutBucketPolicy: %v", err)
+	}
+	if got, err := cli.GetBucketPolicy(ctx, "media"); err != nil || got != policy {
+		t.Errorf("policy = %q, %v", got, err)
+	}
+	if err := cli.DeleteBucketPolicy(ctx, "media"); err != nil {
+		t.Fatalf("DeleteBucketPolicy: %v", err)
+	}
+
+	rules := []LifecycleRule{
+		{ID: "logs", Prefix: "logs/", Enabled: true, ExpirationDays: 30, AbortIncompleteUploadDays: 7},
+		{ID: "old-versions", Enabled: false, NoncurrentVersionExpirationDays: 90},
+	}
+	if err := cli.PutBucketLifecycle(ctx, "media", rules); err != nil {
+		t.Fatalf("PutBucketLifecycle: %v", err)
+	}
+	got, err := cli.GetBucketLifecycle(ctx, "media")
+	if err != nil {
+		t.Fatalf("GetBucketLifecycle: %v", err)
+	}
+	if !reflect.DeepEqual(got, rules) {
+		t.Errorf("lifecycle =\n%+v\nwant\n%+v", got, rules)
+	}
+	if err := cli.DeleteBucketLifecycle(ctx, "media"); err != nil {
+		t.Fatalf("DeleteBucketLifecycle: %v", err)
+	}
+	if _, err := cli.GetBucketLifecycle(ctx, "media"); !IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+
+	if err := cli.DeleteBucket(ctx, "media"); err != nil {
+		t.Fatalf("DeleteBucket: %v", err)
+	}
+	if srv.HasBucket("media") {
+		t.Error("bucket still exists after delete")
+	}
+}
+
+func TestListObjectsPaginates(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	srv.CreateBucket("media")
+	for _, k := range []string{"a/1", "a/2", "a/3", "b/1", "a/4"} {
+		srv.PutObject("media", k, []byte(strings.Repeat("x", len(k))))
+	}
+	cli, err := New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	objects, err := cli.ListObjects(ctx, "media", "a/")
+	if err != nil {
+		t.Fatalf("ListObjects: %v", err)
+	}
+	var keys []string
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+		if o.Size != 3 || o.ETag == "" || o.LastModified.IsZero() {
+			t.Errorf("unexpected summary %+v", o)
+		}
+	}
+	if want := []string{"a/1", "a/2", "a/3", "a/4"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	versions, err := cli.ListObjectVersions(ctx, "media")
+	if err != nil {
+		t.Fatalf("ListObjectVersions: %v", err)
+	}
+	if len(versions) != 5 {
+		t.Fatalf("got %d versions, want 5", len(versions))
+	}
+	for _, v := range versions {
+		if err := cli.DeleteObjectVersion(ctx, "media", v.Key, v.VersionID); err != nil {
+			t.Fatalf("DeleteObjectVersion(%s): %v", v.Key, err)
+		}
+	}
+	if err := cli.DeleteBucket(ctx, "media"); err != nil {
+		t.Errorf("DeleteBucket after emptying: %v", err)
+	}
+}
+
+func TestListBuckets(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+	srv.CreateBucket("media")
+	srv.CreateBucket("backups")
+	cli, err := New(srv.URL, "", "ak", "sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buckets, err := cli.ListBuckets(context.Background())
+	if err != nil {
+		t.Fatalf("ListBuckets: %v", err)
+	}
+	var names []string
+	for _, b := range buckets {
+		names = append(names, b.Name)
+		if b.CreationDate.IsZero() {
+			t.Errorf("bucket %s has no creation date", b.Name)
+		}
+	}
+	if want := []string{"backups", "media"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("buckets = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,177 @@
+// Package s3api is a small client for S3-compatible object storage, such as the
+// CLO S3 service. It signs requests with AWS Signature Version 4 and addresses
+// buckets path-style, which every S3-compatible server accepts. Only the
+// operations the provider needs are implemented.
+package s3api
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultRegion is used for signing when no region is configured. S3-compatible
+// servers that have no notion of regions accept it.
+const DefaultRegion = "us-east-1"
+
+// Client talks to one S3 endpoint with one access key pair.
+type Client struct {
+	endpoint  *url.URL
+	region    string
+	accessKey string
+	secretKey string
+	http      *http.Client
+	now       func() time.Time
+}
+
+// New builds a client for endpoint (e.g. https://s3.clo.ru). An empty region
+// falls back to DefaultRegion.
+func New(endpoint, region, accessKey, secretKey string) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("s3api: endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("s3api: endpoint %q must be an http(s) URL", endpoint)
+	}
+	if region == "" {
+		region = DefaultRegion
+	}
+	return &Client{
+		endpoint:  u,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		http:      http.DefaultClient,
+		now:       time.Now,
+	}, nil
+}
+
+// Error is a non-2xx response. Code and Message come from the S3 XML error body
+// when the server sends one.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("s3api: %s %s: status %d: %s: %s", e.Method, e.Path, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("s3api: %s %s: status %d", e.Method, e.Path, e.StatusCode)
+}
+
+// IsNotFound reports whether err is a 404 from the server (missing bucket,
+// object or sub-resource).
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// HasCode reports whether err is a server error with the S3 error code, such as
+// "BucketNotEmpty".
+func HasCode(err error, code string) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == code
+}
+
+// request describes one call. body may be nil; payloadHash is the hex SHA-256
+// of the body, or unsignedPayload for streamed bodies.
+type request struct {
+	method      string
+	bucket      string
+	key         string
+	query       url.Values
+	header      http.Header
+	body        io.Reader
+	size        int64
+	payloadHash string
+}
+
+// send signs and sends r and returns the response when it is 2xx. The caller
+// closes the body.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	u := *c.endpoint
+	u.Path = c.endpoint.Path + objectPath(r.bucket, r.key)
+	u.RawQuery = r.query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), r.body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range r.header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if r.body != nil {
+		req.ContentLength = r.size
+	}
+	payloadHash := r.payloadHash
+	if payloadHash == "" {
+		payloadHash = emptyPayloadHash
+	}
+	sign(req, payloadHash, c.accessKey, c.secretKey, c.region, c.now())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, responseError(r.method, u.Path, resp)
+	}
+	return resp, nil
+}
+
+// call sends r and discards the response body, decoding it into out when out is
+// non-nil.
+func (c *Client) call(ctx context.Context, r request, out interface{}) (http.Header, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := xml.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("s3api: decode %s %s response: %w", r.method, objectPath(r.bucket, r.key), err)
+		}
+	} else {
+		_, _ = io.Copy(io.Discard, resp.Body)
+	}
+	return resp.Header, nil
+}
+
+func responseError(method, path string, resp *http.Response) error {
+	e := &Error{Method: method, Path: path, StatusCode: resp.StatusCode}
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); err == nil && len(data) > 0 {
+		if xml.Unmarshal(data, &body) == nil {
+			e.Code, e.Message = body.Code, body.Message
+		}
+	}
+	return e
+}
+
+// objectPath is the path-style path of a bucket or an object in it.
+func objectPath(bucket, key string) string {
+	switch {
+	case bucket == "":
+		return "/"
+	case key == "":
+		return "/" + bucket
+	}
+	return "/" + bucket + "/" + key
+}
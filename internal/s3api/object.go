@@ -0,0 +1,98 @@
+package s3api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metaPrefix prefixes user metadata headers on objects.
+const metaPrefix = "X-Amz-Meta-"
+
+// ObjectInfo is what HeadObject reports about an object. ETag is unquoted.
+type ObjectInfo struct {
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+	Metadata     map[string]string
+}
+
+// PutObjectOptions are the optional headers of an upload. Metadata keys are
+// sent as x-amz-meta-<key> and come back lower-cased.
+type PutObjectOptions struct {
+	ContentType string
+	Metadata    map[string]string
+}
+
+// PutObject uploads size bytes from body to bucket/key and returns the new
+// object's ETag. The body is streamed unsigned, so it does not need to be held
+// in memory; size must be exact.
+func (c *Client) PutObject(ctx context.Context, bucket, key string, body io.Reader, size int64, opts PutObjectOptions) (string, error) {
+	h := http.Header{}
+	if opts.ContentType != "" {
+		h.Set("Content-Type", opts.ContentType)
+	}
+	for k, v := range opts.Metadata {
+		h.Set(metaPrefix+k, v)
+	}
+	if body == nil || size == 0 {
+		body = http.NoBody
+	}
+	resp, err := c.call(ctx, request{
+		method:      http.MethodPut,
+		bucket:      bucket,
+		key:         key,
+		header:      h,
+		body:        body,
+		size:        size,
+		payloadHash: unsignedPayload,
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(resp.Get("ETag"), `"`), nil
+}
+
+// HeadObject returns the object's size, ETag, content type and metadata.
+func (c *Client) HeadObject(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	h, err := c.call(ctx, request{method: http.MethodHead, bucket: bucket, key: key}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return objectInfoFromHeader(h), nil
+}
+
+// GetObject opens the object for reading. The caller closes the returned body.
+func (c *Client) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, *ObjectInfo, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, bucket: bucket, key: key})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, objectInfoFromHeader(resp.Header), nil
+}
+
+// DeleteObject deletes the object. Deleting a missing object succeeds.
+func (c *Client) DeleteObject(ctx context.Context, bucket, key string) error {
+	_, err := c.call(ctx, request{method: http.MethodDelete, bucket: bucket, key: key}, nil)
+	return err
+}
+
+func objectInfoFromHeader(h http.Header) *ObjectInfo {
+	info := &ObjectInfo{
+		ETag:        strings.Trim(h.Get("ETag"), `"`),
+		ContentType: h.Get("Content-Type"),
+		Metadata:    map[string]string{},
+	}
+	info.Size, _ = strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+	info.LastModified, _ = http.ParseTime(h.Get("Last-Modified"))
+	for k, vs := range h {
+		if strings.HasPrefix(k, metaPrefix) && len(vs) > 0 {
+			info.Metadata[strings.ToLower(strings.TrimPrefix(k, metaPrefix))] = vs[0]
+		}
+	}
+	return info
+}
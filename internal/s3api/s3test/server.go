@@ -0,0 +1,389 @@
+// Package s3test provides an in-memory S3 stand-in for tests. It implements the
+// subset of the S3 API that internal/s3api uses, with path-style addressing. It
+// checks that requests are signed but does not verify the signatures.
+//
+// A bucket's versioning status is stored but objects are not versioned: each
+// key holds a single version with the ID "null", as in an unversioned bucket.
+package s3test
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Object is a stored object.
+type Object struct {
+	Data         []byte
+	ContentType  string
+	Metadata     map[string]string
+	ETag         string
+	LastModified time.Time
+}
+
+// Server is a running stand-in. Use URL as the client endpoint.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	// PageSize caps the keys returned by one listing page, to exercise
+	// pagination. Zero means 1000, as in S3.
+	PageSize int
+}
+
+type bucket struct {
+	created    time.Time
+	objects    map[string]*Object
+	versioning string
+	policy     []byte
+	lifecycle  []byte
+}
+
+// NewServer starts a stand-in with no buckets. Close it when done.
+func NewServer() *Server {
+	s := &Server{buckets: map[string]*bucket{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// CreateBucket creates an empty bucket, as a test fixture.
+func (s *Server) CreateBucket(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.buckets[name]; !ok {
+		s.buckets[name] = newBucket()
+	}
+}
+
+func newBucket() *bucket {
+	return &bucket{created: time.Now().UTC().Truncate(time.Second), objects: map[string]*Object{}}
+}
+
+// HasBucket reports whether the bucket exists.
+func (s *Server) HasBucket(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.buckets[name]
+	return ok
+}
+
+// Object returns a copy of the stored object, or nil.
+func (s *Server) Object(bucket, key string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.buckets[bucket]
+	if b == nil || b.objects[key] == nil {
+		return nil
+	}
+	cp := *b.objects[key]
+	return &cp
+}
+
+// PutObject stores data under bucket/key, as a test fixture. The bucket must
+// exist.
+func (s *Server) PutObject(bucket, key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := md5.Sum(data)
+	s.buckets[bucket].objects[key] = &Object{
+		Data:         data,
+		Metadata:     map[string]string{},
+		ETag:         hex.EncodeToString(sum[:]),
+		LastModified: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=") {
+		writeError(w, http.StatusForbidden, "AccessDenied", "request is not signed")
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case bucket == "" && r.Method == http.MethodGet:
+		s.writeBuckets(w)
+	case bucket == "":
+		writeError(w, http.StatusNotImplemented, "NotImplemented", "service operations are not supported")
+	case key == "":
+		s.serveBucket(w, r, bucket)
+	default:
+		s.serveObject(w, r, bucket, key)
+	}
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, name string) {
+	b, exists := s.buckets[name]
+	q := r.URL.Query()
+	if r.Method == http.MethodPut && len(q) == 0 {
+		if exists {
+			writeError(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "bucket already exists")
+			return
+		}
+		s.buckets[name] = newBucket()
+		return
+	}
+	if !exists {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "the bucket does not exist")
+		return
+	}
+	switch {
+	case q.Has("versioning"):
+		s.serveVersioning(w, r, b)
+	case q.Has("policy"):
+		serveDocument(w, r, &b.policy, "application/json", "NoSuchBucketPolicy")
+	case q.Has("lifecycle"):
+		serveDocument(w, r, &b.lifecycle, "application/xml", "NoSuchLifecycleConfiguration")
+	case q.Has("versions") && r.Method == http.MethodGet:
+		writeVersions(w, name, b.objects)
+	case r.Method == http.MethodHead:
+	case r.Method == http.MethodDelete:
+		if len(b.objects) > 0 {
+			writeError(w, http.StatusConflict, "BucketNotEmpty", "the bucket is not empty")
+			return
+		}
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		s.writeListing(w, name, b.objects, q.Get("prefix"), q.Get("continuation-token"))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func (s *Server) serveVersioning(w http.ResponseWriter, r *http.Request, b *bucket) {
+	type config struct {
+		XMLName xml.Name `xml:"VersioningConfiguration"`
+		Status  string   `xml:"Status,omitempty"`
+	}
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(config{Status: b.versioning})
+	case http.MethodPut:
+		var c config
+		if err := xml.NewDecoder(r.Body).Decode(&c); err != nil {
+			writeError(w, http.StatusBadRequest, "MalformedXML", err.Error())
+			return
+		}
+		if c.Status != "Enabled" && c.Status != "Suspended" {
+			writeError(w, http.StatusBadRequest, "IllegalVersioningConfigurationException", "status must be Enabled or Suspended")
+			return
+		}
+		b.versioning = c.Status
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+// serveDocument stores, returns and deletes a sub-resource document such as a
+// policy. Uploads must carry a matching Content-MD5.
+func serveDocument(w http.ResponseWriter, r *http.Request, doc *[]byte, contentType, missingCode string) {
+	switch r.Method {
+	case http.MethodGet:
+		if *doc == nil {
+			writeError(w, http.StatusNotFound, missingCode, "the configuration does not exist")
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(*doc)
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		sum := md5.Sum(data)
+		if r.Header.Get("Content-MD5") != base64.StdEncoding.EncodeToString(sum[:]) {
+			writeError(w, http.StatusBadRequest, "InvalidDigest", "Content-MD5 does not match the body")
+			return
+		}
+		if len(data) == 0 {
+			writeError(w, http.StatusBadRequest, "MalformedXML", "empty document")
+			return
+		}
+		*doc = data
+	case http.MethodDelete:
+		*doc = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	b, ok := s.buckets[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "the bucket does not exist")
+		return
+	}
+	objects := b.objects
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		if r.ContentLength >= 0 && int64(len(data)) != r.ContentLength {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", "body shorter than Content-Length")
+			return
+		}
+		sum := md5.Sum(data)
+		o := &Object{
+			Data:         data,
+			ContentType:  r.Header.Get("Content-Type"),
+			Metadata:     map[string]string{},
+			ETag:         hex.EncodeToString(sum[:]),
+			LastModified: time.Now().UTC().Truncate(time.Second),
+		}
+		for k, vs := range r.Header {
+			if strings.HasPrefix(k, "X-Amz-Meta-") && len(vs) > 0 {
+				o.Metadata[strings.ToLower(strings.TrimPrefix(k, "X-Amz-Meta-"))] = vs[0]
+			}
+		}
+		objects[key] = o
+		w.Header().Set("ETag", `"`+o.ETag+`"`)
+	case http.MethodGet, http.MethodHead:
+		o, ok := objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "the key does not exist")
+			return
+		}
+		h := w.Header()
+		h.Set("ETag", `"`+o.ETag+`"`)
+		h.Set("Content-Length", strconv.Itoa(len(o.Data)))
+		h.Set("Last-Modified", o.LastModified.Format(http.TimeFormat))
+		if o.ContentType != "" {
+			h.Set("Content-Type", o.ContentType)
+		}
+		for k, v := range o.Metadata {
+			h.Set("X-Amz-Meta-"+k, v)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(o.Data)
+		}
+	case http.MethodDelete:
+		if v := r.URL.Query().Get("versionId"); v != "" && v != "null" {
+			writeError(w, http.StatusNotFound, "NoSuchVersion", "the version does not exist")
+			return
+		}
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+// writeBuckets lists every bucket, as if all were owned by the caller.
+func (s *Server) writeBuckets(w http.ResponseWriter) {
+	type entry struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	}
+	out := struct {
+		XMLName xml.Name `xml:"ListAllMyBucketsResult"`
+		Buckets []entry  `xml:"Buckets>Bucket"`
+	}{}
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out.Buckets = append(out.Buckets, entry{Name: name, CreationDate: s.buckets[name].created.Format(time.RFC3339)})
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(out)
+}
+
+// writeListing writes one ListObjectsV2 page. The continuation token is the
+// last key of the previous page.
+func (s *Server) writeListing(w http.ResponseWriter, bucket string, objects map[string]*Object, prefix, token string) {
+	type content struct {
+		Key          string `xml:"Key"`
+		Size         int    `xml:"Size"`
+		ETag         string `xml:"ETag"`
+		LastModified string `xml:"LastModified"`
+	}
+	out := struct {
+		XMLName               xml.Name  `xml:"ListBucketResult"`
+		Name                  string    `xml:"Name"`
+		Prefix                string    `xml:"Prefix"`
+		KeyCount              int       `xml:"KeyCount"`
+		IsTruncated           bool      `xml:"IsTruncated"`
+		NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+		Contents              []content `xml:"Contents"`
+	}{Name: bucket, Prefix: prefix}
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		if strings.HasPrefix(k, prefix) && k > token {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+	if len(keys) > pageSize {
+		keys = keys[:pageSize]
+		out.IsTruncated = true
+		out.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, k := range keys {
+		o := objects[k]
+		out.Contents = append(out.Contents, content{
+			Key:          k,
+			Size:         len(o.Data),
+			ETag:         `"` + o.ETag + `"`,
+			LastModified: o.LastModified.Format(time.RFC3339),
+		})
+	}
+	out.KeyCount = len(out.Contents)
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(out)
+}
+
+// writeVersions lists every object as its only version, "null".
+func writeVersions(w http.ResponseWriter, bucket string, objects map[string]*Object) {
+	type version struct {
+		Key       string `xml:"Key"`
+		VersionID string `xml:"VersionId"`
+		IsLatest  bool   `xml:"IsLatest"`
+		Size      int    `xml:"Size"`
+	}
+	out := struct {
+		XMLName  xml.Name  `xml:"ListVersionsResult"`
+		Name     string    `xml:"Name"`
+		Versions []version `xml:"Version"`
+	}{Name: bucket}
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out.Versions = append(out.Versions, version{Key: k, VersionID: "null", IsLatest: true, Size: len(objects[k].Data)})
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(out)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}